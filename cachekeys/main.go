@@ -3,30 +3,97 @@ package cachekeys
 const (
 	QueuePrefix = "oc3:q:feed_"
 
+	// PendingPrefix and PendingSuffix frame the queue name in the feed queue
+	// pending hash keys: oc3:h:feed_<queue name>_pending
+	PendingPrefix = "oc3:h:feed_"
+	PendingSuffix = "_pending"
+
+	// FeedProcessingL is the prefix of the worker processing lists, where the
+	// worker moves the feed queue entries it runs. The key is suffixed by
+	// "<worker id>:<queue key>".
+	FeedProcessingL = "oc3:l:feed_processing:"
+
+	// FeedAttemptsH counts the failed attempts of the feed queue entries,
+	// indexed by "<queue key> <entry>".
+	FeedAttemptsH = "oc3:h:feed_attempts"
+
+	// FeedDeadLetterL is the list of the feed queue entries that reached the
+	// maximum number of attempts.
+	FeedDeadLetterL = "oc3:l:feed_dead_letter"
+
+	// WorkerHeartbeat is the prefix of the worker heartbeat keys, expiring
+	// when the worker stops refreshing them. The key is suffixed by the
+	// worker id.
+	WorkerHeartbeat = "oc3:worker_heartbeat:"
+
+	// SchedulerLease is the prefix of the scheduler task lease keys, valued
+	// by the id of the scheduler executing the task and expiring when it
+	// stops renewing them. The key is suffixed by the task name.
+	SchedulerLease = "oc3:scheduler_lease:"
+
+	// FeederRateLimitH is the prefix of the feeder per node token bucket
+	// hashes. The key is suffixed by "<operationId>:<node id>".
+	FeederRateLimitH = "oc3:h:feeder_rate_limit:"
+
+	// DashboardSeverityRulesP is the channel where the server notifies the
+	// dashboard severity rules changes, to reload in the workers.
+	DashboardSeverityRulesP = "oc3:p:dashboard_severity_rules"
+
 	FeedDaemonPingQ        = "oc3:q:feed_daemon_ping"
 	FeedDaemonPingH        = "oc3:h:feed_daemon_ping"
 	FeedDaemonPingPendingH = "oc3:h:feed_daemon_ping_pending"
 
-	FeedDaemonStatusChangesH = "oc3:h:feed_daemon_status_changes"
-	FeedDaemonStatusH        = "oc3:h:feed_daemon_status"
-	FeedDaemonStatusQ        = "oc3:q:feed_daemon_status"
-	FeedDaemonStatusPendingH = "oc3:h:feed_daemon_status_pending"
+	// FeedDaemonStatusChangesS is the prefix of the per node sets of the
+	// daemon status changes not yet applied by the worker. The key is
+	// suffixed by the node id.
+	FeedDaemonStatusChangesS = "oc3:s:feed_daemon_status_changes:"
+
+	// FeedDaemonStatusTakenS is the prefix of the per node sets of the
+	// daemon status changes taken by a worker job, removed when the job
+	// commits. The key is suffixed by the node id.
+	FeedDaemonStatusTakenS = "oc3:s:feed_daemon_status_taken:"
+
+	// FeedDaemonEventsH is the prefix of the per cluster hashes of the
+	// cluster daemon status maintained from the daemon events, and seeded
+	// by the v3 daemon status. The key is suffixed by the cluster id. The
+	// fields are the space separated keys of the v3 daemon status
+	// data.cluster values, like "node <nodename> instance <path> status",
+	// and the values are json encoded.
+	FeedDaemonEventsH        = "oc3:h:feed_daemon_events:"
+	FeedDaemonEventsQ        = "oc3:q:feed_daemon_events"
+	FeedDaemonEventsP        = "oc3:p:feed_daemon_events"
+	FeedDaemonEventsPendingH = "oc3:h:feed_daemon_events_pending"
+
+	// FeedDaemonEventsNodeS is the prefix of the per node sets of the
+	// FeedDaemonEventsH fields of the node. The key is suffixed by
+	// "<cluster id>:<nodename>".
+	FeedDaemonEventsNodeS = "oc3:s:feed_daemon_events_node:"
+
+	FeedDaemonStatusGenerationH = "oc3:h:feed_daemon_status_generation"
+	FeedDaemonStatusH           = "oc3:h:feed_daemon_status"
+	FeedDaemonStatusQ           = "oc3:q:feed_daemon_status"
+	FeedDaemonStatusP           = "oc3:p:feed_daemon_status"
+	FeedDaemonStatusPendingH    = "oc3:h:feed_daemon_status_pending"
 
 	FeedInstanceResourceInfoH        = "oc3:h:feed_instance_resource_info"
 	FeedInstanceResourceInfoQ        = "oc3:q:feed_instance_resource_info"
+	FeedInstanceResourceInfoP        = "oc3:p:feed_instance_resource_info"
 	FeedInstanceResourceInfoPendingH = "oc3:h:feed_instance_resource_info_pending"
 
 	FeedNodeDiskH        = "oc3:h:feed_node_disk"
 	FeedNodeDiskQ        = "oc3:q:feed_node_disk"
+	FeedNodeDiskP        = "oc3:p:feed_node_disk"
 	FeedNodeDiskPendingH = "oc3:h:feed_node_disk_pending"
 
 	FeedObjectConfigForClusterIDH = "oc3:h:feed_object_config_for_cluster_id"
 
 	FeedObjectConfigH        = "oc3:h:feed_object_config"
 	FeedObjectConfigQ        = "oc3:q:feed_object_config"
+	FeedObjectConfigP        = "oc3:p:feed_object_config"
 	FeedObjectConfigPendingH = "oc3:h:feed_object_config_pending"
 
 	FeedSystemQ        = "oc3:q:feed_system"
+	FeedSystemP        = "oc3:p:feed_system"
 	FeedSystemH        = "oc3:h:feed_system"
 	FeedSystemPendingH = "oc3:h:feed_system_pending"
 
@@ -37,10 +104,18 @@ const (
 
 	FeedInstanceActionH        = "oc3:h:feed_instance_action"
 	FeedInstanceActionQ        = "oc3:q:feed_instance_action"
+	FeedInstanceActionP        = "oc3:p:feed_instance_action"
 	FeedInstanceActionPendingH = "oc3:h:feed_instance_action_pending"
 
 	FeedSysreportQ = "oc3:q:feed_sysreport"
 
-	FeedChecksH = "oc3:h:feed_checks"
-	FeedChecksQ = "oc3:q:feed_checks"
+	// SysreportLock is the prefix of the node sysreport repository lock
+	// keys, valued by the token of the worker job updating the repository
+	// and expiring when it stops renewing them. The key is suffixed by the
+	// node id.
+	SysreportLock = "oc3:sysreport_lock:"
+
+	FeedChecksH        = "oc3:h:feed_checks"
+	FeedChecksQ        = "oc3:q:feed_checks"
+	FeedChecksPendingH = "oc3:h:feed_checks_pending"
 )
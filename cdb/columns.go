@@ -0,0 +1,47 @@
+package cdb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// columnPresence is the cached result of a column existence check.
+	columnPresence struct {
+		exists    bool
+		checkedAt time.Time
+	}
+)
+
+var (
+	// columnPresences caches the existence of the columns added by the
+	// schema upgrades, keyed by "<table>.<column>".
+	columnPresences sync.Map
+
+	// columnPresenceRecheck is the delay before checking again a missing
+	// column, so the upgraded schema is used without restart.
+	columnPresenceRecheck = time.Minute
+)
+
+// HasColumn returns true if the column exists in the table of the current
+// database. The ingestion queries use it to keep working with the columns of
+// schema/upgrade.sql not yet deployed. A present column is cached forever, a
+// missing column is checked again after a minute.
+func (oDb *DB) HasColumn(ctx context.Context, table, column string) (bool, error) {
+	key := table + "." + column
+	if v, ok := columnPresences.Load(key); ok {
+		p := v.(columnPresence)
+		if p.exists || time.Since(p.checkedAt) < columnPresenceRecheck {
+			return p.exists, nil
+		}
+	}
+	const query = "SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?"
+	var n int
+	if err := oDb.DB.QueryRowContext(ctx, query, table, column).Scan(&n); err != nil {
+		return false, fmt.Errorf("hasColumn %s: %w", key, err)
+	}
+	columnPresences.Store(key, columnPresence{exists: n > 0, checkedAt: time.Now()})
+	return n > 0, nil
+}
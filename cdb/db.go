@@ -31,6 +31,11 @@ type (
 		dbPool *sql.DB
 		HasTx  bool
 
+		// ObjectIDsInTx creates the object ids in the current transaction
+		// instead of a separate committed transaction, so the ids are not
+		// allocated when the transaction is rolled back.
+		ObjectIDsInTx bool
+
 		Metrics *Metrics
 	}
 
@@ -116,6 +116,15 @@ func (oDb *DB) ActionQSetQueued(ctx context.Context, queuedIds []int) error {
 	return err
 }
 
+// ActionQResetDequeued resets the dequeue date of the waiting actions, so
+// they are dequeued again.
+func (oDb *DB) ActionQResetDequeued(ctx context.Context, ids []int) error {
+	placeholders, args := getPlaceholdersAndArgs(ids)
+	request := fmt.Sprintf(`update action_queue set date_dequeued=0 where id in (%s) and status='W'`, strings.Join(placeholders, ","))
+	_, err := oDb.ExecContext(ctx, request, args...)
+	return err
+}
+
 func (oDb *DB) ActionQPurge(ctx context.Context) error {
 	request := `delete from action_queue where date_dequeued<date_sub(now(), interval 1 day) and status in ('T', 'C')`
 	_, err := oDb.ExecContext(ctx, request)
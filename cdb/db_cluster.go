@@ -2,23 +2,160 @@ package cdb
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opensvc/oc3/schema"
 )
 
+// UpdateClustersData saves the last raw daemon status of the cluster, and
+// its update time if the clusters.updated column of schema/upgrade.sql is
+// deployed. It also removes the cluster row keyed by its name, saved by the
+// previous versions of the worker.
 func (oDb *DB) UpdateClustersData(ctx context.Context, clusterName, clusterID, data string) error {
-	// TODO: verify if still needed, we can't assert things here
-	// +--------------+--------------+------+-----+---------+----------------+
-	// | Field        | Type         | Null | Key | Default | Extra          |
-	// +--------------+--------------+------+-----+---------+----------------+
-	// | id           | int(11)      | NO   | PRI | NULL    | auto_increment |
-	// | cluster_id   | char(36)     | YES  | UNI |         |                |
-	// | cluster_name | varchar(128) | NO   |     | NULL    |                |
-	// | cluster_data | longtext     | YES  |     | NULL    |                |
-	// +--------------+--------------+------+-----+---------+----------------+
+	// CREATE TABLE `clusters` (
+	//  `id` int(11) NOT NULL AUTO_INCREMENT,
+	//  `cluster_id` char(36) DEFAULT '',
+	//  `cluster_name` varchar(128) NOT NULL,
+	//  `cluster_data` longtext DEFAULT NULL,
+	//  `updated` timestamp NOT NULL DEFAULT current_timestamp(),
+	//  PRIMARY KEY (`id`),
+	//  UNIQUE KEY `cluster_id` (`cluster_id`)
+	//) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
 	const (
 		query = `INSERT INTO clusters (cluster_name, cluster_id, cluster_data)
 			VALUES (?, ?, ?)
 			ON DUPLICATE KEY UPDATE cluster_name = ?, cluster_data = ?`
+
+		queryWithUpdated = `INSERT INTO clusters (cluster_name, cluster_id, cluster_data, updated)
+			VALUES (?, ?, ?, NOW())
+			ON DUPLICATE KEY UPDATE cluster_name = ?, cluster_data = ?, updated = NOW()`
+
+		queryDeleteByName = `DELETE FROM clusters WHERE cluster_id = ? AND cluster_name = ?`
+	)
+	hasUpdated, err := oDb.HasColumn(ctx, "clusters", "updated")
+	if err != nil {
+		return fmt.Errorf("updateClustersData: %w", err)
+	}
+	q := query
+	if hasUpdated {
+		q = queryWithUpdated
+	}
+	if _, err := oDb.ExecContext(ctx, q, clusterName, clusterID, data, clusterName, data); err != nil {
+		return fmt.Errorf("updateClustersData: %w", err)
+	}
+	if clusterName != clusterID {
+		if _, err := oDb.ExecContext(ctx, queryDeleteByName, clusterName, clusterName); err != nil {
+			return fmt.Errorf("updateClustersData: delete the row keyed by name: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildClustersQuery returns the clusters query, restricted to the clusters
+// with a node of an app the groups are responsible for, unless isManager.
+func buildClustersQuery(groups []string, isManager bool, selectExprs []string) (string, []any) {
+	q := From(schema.TClusters).
+		RawSelect(selectExprs...)
+
+	if !isManager {
+		cleanGroups := cleanGroups(groups)
+		if len(cleanGroups) == 0 {
+			q = q.WhereRaw("1=0")
+		} else {
+			args := make([]any, len(cleanGroups))
+			for i, g := range cleanGroups {
+				args[i] = g
+			}
+			q = q.WhereRaw(
+				"clusters.cluster_id IN ("+
+					"SELECT n.cluster_id FROM nodes n"+
+					" JOIN apps a ON a.app = n.app"+
+					" JOIN apps_responsibles ar ON ar.app_id = a.id"+
+					" JOIN auth_group ag ON ag.id = ar.group_id"+
+					" WHERE ag.role IN ("+Placeholders(len(cleanGroups))+")"+
+					")",
+				args...,
+			)
+		}
+	} else {
+		// skip the rows keyed by name saved by the previous versions of
+		// the worker, not yet removed by UpdateClustersData
+		q = q.Where(schema.ClustersID, ">", 0).
+			WhereRaw("clusters.cluster_id != clusters.cluster_name")
+	}
+
+	query, args, err := q.Build()
+	if err != nil {
+		panic(fmt.Sprintf("buildClustersQuery: %v", err))
+	}
+	return query, args
+}
+
+func (oDb *DB) GetClusters(ctx context.Context, p ListParams) ([]map[string]any, error) {
+	query, args := buildClustersQuery(p.Groups, p.IsManager, p.SelectExprs)
+	if gb := p.GroupByClause(""); gb != "" {
+		query += " " + gb
+	}
+	query += " " + p.OrderByClause("clusters.cluster_name, clusters.id")
+	query, args = appendLimitOffset(query, args, p.Limit, p.Offset)
+
+	rows, err := oDb.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("getClusters: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanRowsToMaps(rows, p.Props, p.TypeHints)
+}
+
+// GetCluster returns the cluster with the cluster id or name clusterID.
+func (oDb *DB) GetCluster(ctx context.Context, clusterID string, p ListParams) ([]map[string]any, error) {
+	query, args := buildClustersQuery(p.Groups, p.IsManager, p.SelectExprs)
+	query += " AND (clusters.cluster_id = ? OR clusters.cluster_name = ?)"
+	args = append(args, clusterID, clusterID)
+	query += " " + p.OrderByClause("clusters.cluster_name, clusters.id")
+	query, args = appendLimitOffset(query, args, p.Limit, p.Offset)
+
+	rows, err := oDb.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("getCluster: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanRowsToMaps(rows, p.Props, p.TypeHints)
+}
+
+// ClusterData returns the last raw daemon status of the cluster with the
+// cluster id clusterID, and its update time, zero if the clusters.updated
+// column is not deployed. The returned data is nil if the cluster is not
+// found.
+func (oDb *DB) ClusterData(ctx context.Context, clusterID string) ([]byte, time.Time, error) {
+	const (
+		query            = `SELECT COALESCE(cluster_data, ''), NULL FROM clusters WHERE cluster_id = ?`
+		queryWithUpdated = `SELECT COALESCE(cluster_data, ''), updated FROM clusters WHERE cluster_id = ?`
+	)
+	var (
+		data    []byte
+		updated sql.NullTime
 	)
-	_, err := oDb.ExecContext(ctx, query, clusterName, clusterID, data, clusterName, data)
-	return err
+	hasUpdated, err := oDb.HasColumn(ctx, "clusters", "updated")
+	if err != nil {
+		return nil, updated.Time, fmt.Errorf("clusterData: %w", err)
+	}
+	q := query
+	if hasUpdated {
+		q = queryWithUpdated
+	}
+	err = oDb.DB.QueryRowContext(ctx, q, clusterID).Scan(&data, &updated)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, updated.Time, nil
+	case err != nil:
+		return nil, updated.Time, fmt.Errorf("clusterData: %w", err)
+	default:
+		return data, updated.Time, nil
+	}
 }
@@ -1262,3 +1262,100 @@ func (oDb *DB) DashboardUpdateCompRsetDiffForSvc(ctx context.Context, svcID stri
 
 	return nil
 }
+
+// DashboardUpdateHeartbeatsNotBeating raises the "heartbeat not beating"
+// alerts of the peer heartbeats not beating for longer than threshold, and
+// the "node pair heartbeats down" alerts of the node pairs with no peer
+// heartbeat beating. The alerts no longer raised are removed.
+func (oDb *DB) DashboardUpdateHeartbeatsNotBeating(ctx context.Context, threshold time.Duration) error {
+	defer logDuration("DashboardUpdateHeartbeatsNotBeating", time.Now())
+	request := `SET @now = NOW()`
+	if _, err := oDb.ExecContext(ctx, request); err != nil {
+		return fmt.Errorf("dashboardUpdateHeartbeatsNotBeating: %w", err)
+	}
+
+	request = `
+		INSERT INTO dashboard
+		SELECT
+		  NULL,
+		  "heartbeat not beating",
+		  "",
+		  IF(nodes.node_env = "PRD", 3, 2),
+		  "heartbeat %(hb)s to %(peer)s not beating since %(since)s",
+		  CONCAT('{"hb": "', hbmon.name, '", "peer": "', COALESCE(peers.nodename, hbmon.peer_node_id), '", "since": "', hbmon.last_beating, '"}'),
+		  @now,
+		  MD5(CONCAT('{"hb": "', hbmon.name, '", "peer": "', hbmon.peer_node_id, '"}')),
+		  nodes.node_env,
+		  @now,
+		  hbmon.node_id,
+		  NULL,
+		  NULL
+		FROM hbmon
+		JOIN nodes ON nodes.node_id = hbmon.node_id
+		LEFT JOIN nodes peers ON peers.node_id = hbmon.peer_node_id
+		WHERE
+		  hbmon.peer_node_id != "" AND
+		  hbmon.beating = 2 AND
+		  hbmon.last_beating < DATE_SUB(@now, INTERVAL ? SECOND)
+		ON DUPLICATE KEY UPDATE
+		  dash_severity = VALUES(dash_severity),
+		  dash_dict = VALUES(dash_dict),
+		  dash_updated = @now,
+		  dash_env = VALUES(dash_env)`
+	if count, err := oDb.execCountContext(ctx, request, int64(threshold.Seconds())); err != nil {
+		return fmt.Errorf("dashboardUpdateHeartbeatsNotBeating: %w", err)
+	} else if count > 0 {
+		oDb.SetChange("dashboard")
+	}
+
+	request = `
+		INSERT INTO dashboard
+		SELECT
+		  NULL,
+		  "node pair heartbeats down",
+		  "",
+		  IF(nodes.node_env = "PRD", 4, 3),
+		  "all heartbeats to %(peer)s are down",
+		  CONCAT('{"peer": "', COALESCE(peers.nodename, pairs.peer_node_id), '"}'),
+		  @now,
+		  MD5(CONCAT('{"peer": "', pairs.peer_node_id, '"}')),
+		  nodes.node_env,
+		  @now,
+		  pairs.node_id,
+		  NULL,
+		  NULL
+		FROM (
+		  SELECT node_id, peer_node_id
+		  FROM hbmon
+		  WHERE peer_node_id != ""
+		  GROUP BY node_id, peer_node_id
+		  HAVING SUM(beating = 1) = 0 AND SUM(beating = 2) > 0
+		) pairs
+		JOIN nodes ON nodes.node_id = pairs.node_id
+		LEFT JOIN nodes peers ON peers.node_id = pairs.peer_node_id
+		ON DUPLICATE KEY UPDATE
+		  dash_severity = VALUES(dash_severity),
+		  dash_dict = VALUES(dash_dict),
+		  dash_updated = @now,
+		  dash_env = VALUES(dash_env)`
+	if count, err := oDb.execCountContext(ctx, request); err != nil {
+		return fmt.Errorf("dashboardUpdateHeartbeatsNotBeating: %w", err)
+	} else if count > 0 {
+		oDb.SetChange("dashboard")
+	}
+
+	request = `
+		DELETE FROM dashboard
+		WHERE
+		  dash_type IN ("heartbeat not beating", "node pair heartbeats down") AND
+		  (
+		    dash_updated < @now OR
+		    dash_updated IS NULL
+		  )`
+	if count, err := oDb.execCountContext(ctx, request); err != nil {
+		return fmt.Errorf("dashboardUpdateHeartbeatsNotBeating: %w", err)
+	} else if count > 0 {
+		oDb.SetChange("dashboard")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type (
+	// DashboardSeverityRule sets the severity of the dashboard alerts of type
+	// DashType for the objects matching App, Env and ObjectPattern, a glob
+	// pattern of the object name. The empty criteria match all. When many
+	// rules match, the rule with the highest Priority is applied.
+	//
+	// CREATE TABLE `dashboard_severity_rules` (
+	//  `id` int(11) NOT NULL AUTO_INCREMENT,
+	//  `priority` int(11) NOT NULL DEFAULT 0,
+	//  `dash_type` varchar(60) NOT NULL DEFAULT '',
+	//  `app` varchar(64) NOT NULL DEFAULT '',
+	//  `env` varchar(10) NOT NULL DEFAULT '',
+	//  `object_pattern` varchar(255) NOT NULL DEFAULT '',
+	//  `severity` int(11) NOT NULL,
+	//  `updated` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	//  PRIMARY KEY (`id`)
+	//) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	DashboardSeverityRule struct {
+		ID            int64  `json:"id"`
+		Priority      int    `json:"priority"`
+		DashType      string `json:"dash_type"`
+		App           string `json:"app"`
+		Env           string `json:"env"`
+		ObjectPattern string `json:"object_pattern"`
+		Severity      int    `json:"severity"`
+	}
+)
+
+const dashboardSeverityRuleColumns = "id, priority, dash_type, app, env, object_pattern, severity"
+
+// DashboardSeverityRules returns the dashboard severity rules by decreasing
+// priority.
+func (oDb *DB) DashboardSeverityRules(ctx context.Context) ([]DashboardSeverityRule, error) {
+	const query = "SELECT " + dashboardSeverityRuleColumns + " FROM dashboard_severity_rules ORDER BY priority DESC, id"
+	rows, err := oDb.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("dashboardSeverityRules: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	l := make([]DashboardSeverityRule, 0)
+	for rows.Next() {
+		var r DashboardSeverityRule
+		if err := rows.Scan(&r.ID, &r.Priority, &r.DashType, &r.App, &r.Env, &r.ObjectPattern, &r.Severity); err != nil {
+			return nil, fmt.Errorf("dashboardSeverityRules scan: %w", err)
+		}
+		l = append(l, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("dashboardSeverityRules rows: %w", err)
+	}
+	return l, nil
+}
+
+// DashboardSeverityRule returns the dashboard severity rule with id, or nil
+// if not found.
+func (oDb *DB) DashboardSeverityRule(ctx context.Context, id int64) (*DashboardSeverityRule, error) {
+	const query = "SELECT " + dashboardSeverityRuleColumns + " FROM dashboard_severity_rules WHERE id = ?"
+	var r DashboardSeverityRule
+	err := oDb.DB.QueryRowContext(ctx, query, id).Scan(&r.ID, &r.Priority, &r.DashType, &r.App, &r.Env, &r.ObjectPattern, &r.Severity)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("dashboardSeverityRule: %w", err)
+	default:
+		return &r, nil
+	}
+}
+
+// InsertDashboardSeverityRule inserts the rule and returns it with its id.
+func (oDb *DB) InsertDashboardSeverityRule(ctx context.Context, r DashboardSeverityRule) (*DashboardSeverityRule, error) {
+	const query = `INSERT INTO dashboard_severity_rules (priority, dash_type, app, env, object_pattern, severity) VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := oDb.DB.ExecContext(ctx, query, r.Priority, r.DashType, r.App, r.Env, r.ObjectPattern, r.Severity)
+	if err != nil {
+		return nil, fmt.Errorf("insertDashboardSeverityRule: %w", err)
+	}
+	if r.ID, err = result.LastInsertId(); err != nil {
+		return nil, fmt.Errorf("insertDashboardSeverityRule lastInsertId: %w", err)
+	}
+	oDb.SetChange("dashboard_severity_rules")
+	return &r, nil
+}
+
+// UpdateDashboardSeverityRule replaces the rule with id r.ID.
+func (oDb *DB) UpdateDashboardSeverityRule(ctx context.Context, r DashboardSeverityRule) error {
+	const query = `UPDATE dashboard_severity_rules SET priority = ?, dash_type = ?, app = ?, env = ?, object_pattern = ?, severity = ? WHERE id = ?`
+	if _, err := oDb.DB.ExecContext(ctx, query, r.Priority, r.DashType, r.App, r.Env, r.ObjectPattern, r.Severity, r.ID); err != nil {
+		return fmt.Errorf("updateDashboardSeverityRule: %w", err)
+	}
+	oDb.SetChange("dashboard_severity_rules")
+	return nil
+}
+
+func (oDb *DB) DeleteDashboardSeverityRule(ctx context.Context, id int64) error {
+	const query = `DELETE FROM dashboard_severity_rules WHERE id = ?`
+	if _, err := oDb.DB.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("deleteDashboardSeverityRule: %w", err)
+	}
+	oDb.SetChange("dashboard_severity_rules")
+	return nil
+}
@@ -99,25 +99,27 @@ type (
 	}
 )
 
-func (oDb *DB) hbByClusterID(ctx context.Context, clusterID string) ([]DBHeartbeat, error) {
+// HBByClusterID returns the heartbeats of the cluster nodes, ordered by
+// node, peer node and name.
+func (oDb *DB) HBByClusterID(ctx context.Context, clusterID string) ([]DBHeartbeat, error) {
 	const (
-		query = "SELECT `id`, `cluster_id`, `node_id`, `peer_node_id`, `driver`, `name`, `desc`, `state`, `beating`, `updated`" +
+		query = "SELECT `id`, `cluster_id`, `node_id`, `peer_node_id`, `driver`, `name`, `desc`, `state`, `beating`, `last_beating`, `updated`" +
 			" FROM `hbmon`" +
-			" WHERE `cluster_id` = ?"
+			" WHERE `cluster_id` = ?" +
+			" ORDER BY `node_id`, `peer_node_id`, `name`"
 	)
 	var (
 		l []DBHeartbeat
 	)
 	rows, err := oDb.DB.QueryContext(ctx, query, clusterID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("hbByClusterID: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
-	var ()
 	for rows.Next() {
 		var hb DBHeartbeat
-		if err = rows.Scan(&hb.ID, &hb.ClusterID, &hb.NodeID, &hb.PeerNodeID, &hb.Driver, &hb.Name, &hb.Desc, &hb.State, &hb.Beating, &hb.Updated); err != nil {
-			return nil, err
+		if err = rows.Scan(&hb.ID, &hb.ClusterID, &hb.NodeID, &hb.PeerNodeID, &hb.Driver, &hb.Name, &hb.Desc, &hb.State, &hb.Beating, &hb.LastBeating, &hb.Updated); err != nil {
+			return nil, fmt.Errorf("hbByClusterID scan: %w", err)
 		}
 		l = append(l, hb)
 	}
@@ -195,6 +197,34 @@ func (oDb *DB) HBLogLastFromClusterID(ctx context.Context, clusterID string) ([]
 	return l, nil
 }
 
+// HBLogByClusterID returns the heartbeat state periods of the cluster
+// nodes ending after since, ordered by node, peer node, name and begin.
+func (oDb *DB) HBLogByClusterID(ctx context.Context, clusterID string, since time.Time) ([]*DBHeartbeatLog, error) {
+	const (
+		query = "SELECT `id`, `node_id`, `peer_node_id`, `name`, `state`, `beating`, `begin`, `end`" +
+			" FROM `hbmon_log`" +
+			" WHERE `cluster_id` = ? AND `end` >= ?" +
+			" ORDER BY `node_id`, `peer_node_id`, `name`, `begin`"
+	)
+	rows, err := oDb.DB.QueryContext(ctx, query, clusterID, since)
+	if err != nil {
+		return nil, fmt.Errorf("hbLogByClusterID: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	l := make([]*DBHeartbeatLog, 0)
+	for rows.Next() {
+		o := DBHeartbeatLog{ClusterID: clusterID}
+		if err := rows.Scan(&o.ID, &o.NodeID, &o.PeerNodeID, &o.Name, &o.State, &o.Beating, &o.Begin, &o.End); err != nil {
+			return nil, fmt.Errorf("hbLogByClusterID scan: %w", err)
+		}
+		l = append(l, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("hbLogByClusterID rows: %w", err)
+	}
+	return l, nil
+}
+
 func (oDb *DB) HBLogLastUpdate(ctx context.Context, l ...*DBHeartbeatLog) error {
 	defer logDuration("HBLogLastUpdate", time.Now())
 	const (
@@ -224,8 +254,8 @@ func (oDb *DB) HBLogLastExtend(ctx context.Context, l ...*DBHeartbeatLog) error
 	}
 	placeholders := strings.Repeat("(?,?,?),", len(l)-1) + "(?,?,?)"
 
-	query := fmt.Sprintf("UPDATE `hbmon_log_last` SET `end` = NOW() WHERE (`node_id`,`peer_node_id`,`name`) IN %s)", placeholders)
-	args := make([]any, 3*len(l))
+	query := fmt.Sprintf("UPDATE `hbmon_log_last` SET `end` = NOW() WHERE (`node_id`,`peer_node_id`,`name`) IN (%s)", placeholders)
+	args := make([]any, 0, 3*len(l))
 	for _, v := range l {
 		args = append(args, v.NodeID, v.PeerNodeID, v.Name)
 	}
@@ -273,7 +303,7 @@ func (o *DBHeartbeat) SameAsLog(logStatus *DBHeartbeatLog) bool {
 func (o *DBHeartbeat) AsLog() *DBHeartbeatLog {
 	return &DBHeartbeatLog{
 		ClusterID:  o.ClusterID,
-		NodeID:     o.ClusterID,
+		NodeID:     o.NodeID,
 		Name:       o.Name,
 		PeerNodeID: o.PeerNodeID,
 		State:      o.State,
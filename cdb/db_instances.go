@@ -52,6 +52,7 @@ type (
 	//  `svc_id` char(36) CHARACTER SET ascii DEFAULT '',
 	//  `mon_smon_status` varchar(32) DEFAULT NULL,
 	//  `mon_smon_global_expect` varchar(32) DEFAULT NULL,
+	//  `mon_merge_rule` varchar(255) DEFAULT '',
 	//  PRIMARY KEY (`ID`),
 	//  UNIQUE KEY `uk_svcmon` (`node_id`,`svc_id`,`mon_vmname`),
 	//  KEY `mon_vmname` (`mon_vmname`),
@@ -78,6 +79,10 @@ type (
 		MonFrozenAt         time.Time
 		MonVmType           string
 		MonUpdated          string
+
+		// MonMergeRule describes the rules that merged the hypervisor and
+		// encap statuses of a container instance.
+		MonMergeRule string
 	}
 
 	/*
@@ -422,15 +427,17 @@ func (oDb *DB) SvcmonLogUpdate(ctx context.Context, l ...*DBInstanceStatusLog) e
 	return nil
 }
 
+// SvcmonUpdate upserts the instances status. The mon_merge_rule column of
+// schema/upgrade.sql is only saved once deployed.
 func (oDb *DB) SvcmonUpdate(ctx context.Context, l ...*DBInstanceStatus) error {
 	defer logDuration("SvcmonUpdate", time.Now())
 	const (
-		insertColList = `(svc_id, node_id, mon_vmname,
+		insertColList = `svc_id, node_id, mon_vmname,
 		    mon_smon_status, mon_smon_global_expect, mon_availstatus, 
 		    mon_overallstatus, mon_ipstatus, mon_diskstatus, mon_fsstatus,
 		    mon_sharestatus, mon_containerstatus, mon_appstatus, mon_syncstatus,
-		    mon_frozen, mon_frozen_at, mon_vmtype, mon_updated)`
-		valueList             = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())"
+		    mon_frozen, mon_frozen_at, mon_vmtype`
+		valueList             = "?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?"
 		onDuplicateAssignment = `mon_smon_status=VALUES(mon_smon_status),
 		    mon_smon_global_expect=VALUES(mon_smon_global_expect),
 		    mon_availstatus=VALUES(mon_availstatus),
@@ -444,24 +451,40 @@ func (oDb *DB) SvcmonUpdate(ctx context.Context, l ...*DBInstanceStatus) error {
 		    mon_syncstatus=VALUES(mon_syncstatus),
 		    mon_frozen=VALUES(mon_frozen),
 		    mon_frozen_at=VALUES(mon_frozen_at),
-		    mon_vmtype=VALUES(mon_vmtype),
-		    mon_updated=NOW()`
+		    mon_vmtype=VALUES(mon_vmtype),`
 	)
 	if len(l) == 0 {
 		return nil
 	}
-	placeholders := strings.Repeat(valueList+", ", len(l)-1) + valueList
-	args := make([]any, 0, 17*len(l))
+	hasMergeRule, err := oDb.HasColumn(ctx, "svcmon", "mon_merge_rule")
+	if err != nil {
+		return err
+	}
+	colList, values, assignments := insertColList, valueList, onDuplicateAssignment
+	if hasMergeRule {
+		colList += ", mon_merge_rule"
+		values += ", ?"
+		assignments += " mon_merge_rule=VALUES(mon_merge_rule),"
+	}
+	colList = "(" + colList + ", mon_updated)"
+	values = "(" + values + ", NOW())"
+	assignments += " mon_updated=NOW()"
+
+	placeholders := strings.Repeat(values+", ", len(l)-1) + values
+	args := make([]any, 0, 18*len(l))
 	for _, v := range l {
 		args = append(args, v.SvcID, v.NodeID, v.MonVmName,
 			v.MonSmonStatus, v.MonSmonGlobalExpect, v.MonAvailStatus,
 			v.MonOverallStatus, v.MonIpStatus, v.MonDiskStatus, v.MonFsStatus,
 			v.MonShareStatus, v.MonContainerStatus, v.MonAppStatus, v.MonSyncStatus,
 			v.MonFrozen, v.MonFrozenAt, v.MonVmType)
+		if hasMergeRule {
+			args = append(args, v.MonMergeRule)
+		}
 	}
 
 	query := fmt.Sprintf("INSERT INTO svcmon %s VALUES %s ON DUPLICATE KEY UPDATE %s",
-		insertColList, placeholders, onDuplicateAssignment)
+		colList, placeholders, assignments)
 
 	// TODO check vs v2
 	if count, err := oDb.execCountContext(ctx, query, args...); err != nil {
@@ -229,6 +229,35 @@ func (oDb *DB) ClusterNodesFromNodeID(ctx context.Context, nodeID string) (dbNod
 	return
 }
 
+// NodesFromClusterID returns the cluster nodes, ordered by nodename. Only
+// the nodename, node_id, cluster_id and app are set.
+func (oDb *DB) NodesFromClusterID(ctx context.Context, clusterID string) ([]*DBNode, error) {
+	defer logDuration("nodesFromClusterID", time.Now())
+	const query = `SELECT nodename, node_id, app FROM nodes WHERE cluster_id = ? ORDER BY nodename`
+	rows, err := oDb.DB.QueryContext(ctx, query, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("nodesFromClusterID: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var dbNodes []*DBNode
+	for rows.Next() {
+		var nodename, nodeID, app sql.NullString
+		if err := rows.Scan(&nodename, &nodeID, &app); err != nil {
+			return nil, fmt.Errorf("nodesFromClusterID scan: %w", err)
+		}
+		dbNodes = append(dbNodes, &DBNode{
+			Nodename:  nodename.String,
+			NodeID:    nodeID.String,
+			ClusterID: clusterID,
+			App:       app.String,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("nodesFromClusterID rows: %w", err)
+	}
+	return dbNodes, nil
+}
+
 func (oDb *DB) NodesFromClusterIDWithNodenames(ctx context.Context, clusterID string, nodes []string) (dbNodes []*DBNode, err error) {
 	defer logDuration("nodesFromClusterIDWithNodenames", time.Now())
 	if len(nodes) == 0 {
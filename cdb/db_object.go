@@ -622,16 +622,12 @@ func (oDb *DB) InsertOrUpdateObjectConfig(ctx context.Context, c *DBObjectConfig
 
 // ObjectIDFindOrCreate ensures a unique svc_id exists for a given svcname and clusterID, creating it if necessary.
 // Returns a boolean indicating if a new ID was created, the svc_id, or an error if the operation fails.
-// It is run inside a locked separate transaction to ensure always success insertion.
+// It is run inside a locked separate transaction to ensure always success insertion, or in the
+// current transaction if oDb.ObjectIDsInTx.
 func (oDb *DB) ObjectIDFindOrCreate(ctx context.Context, svcname, clusterID string) (isNew bool, svcID string, err error) {
-	const (
-		queryInsertID = "INSERT IGNORE INTO `service_ids` (`svcname`, `cluster_id`) VALUES (?, ?)"
-		querySearchID = "SELECT `svc_id` FROM `service_ids` WHERE `svcname` = ? AND `cluster_id` = ? LIMIT 1"
-	)
-	var (
-		result       sql.Result
-		rowsAffected int64
-	)
+	if oDb.ObjectIDsInTx && oDb.HasTx {
+		return objectIDFindOrCreate(ctx, oDb.DB, svcname, clusterID)
+	}
 	oDb.DBLck.Lock()
 	defer oDb.DBLck.Unlock()
 
@@ -640,28 +636,40 @@ func (oDb *DB) ObjectIDFindOrCreate(ctx context.Context, svcname, clusterID stri
 		err = fmt.Errorf("can't begin transaction: %w", err1)
 		return
 	}
+	if isNew, svcID, err = objectIDFindOrCreate(ctx, tx, svcname, clusterID); err != nil {
+		_ = tx.Rollback()
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+func objectIDFindOrCreate(ctx context.Context, tx DBOperater, svcname, clusterID string) (isNew bool, svcID string, err error) {
+	const (
+		queryInsertID = "INSERT IGNORE INTO `service_ids` (`svcname`, `cluster_id`) VALUES (?, ?)"
+		querySearchID = "SELECT `svc_id` FROM `service_ids` WHERE `svcname` = ? AND `cluster_id` = ? LIMIT 1"
+	)
+	var (
+		result       sql.Result
+		rowsAffected int64
+	)
 	if result, err = tx.ExecContext(ctx, queryInsertID, svcname, clusterID); err != nil {
 		err = fmt.Errorf("INSERT IGNORE INTO `service_ids`: %w", err)
-		_ = tx.Rollback()
 		return
 	} else if result == nil {
 		err = fmt.Errorf("INSERT IGNORE INTO `service_ids` returned nil result")
-		_ = tx.Rollback()
 		return
 	}
 	if rowsAffected, err = result.RowsAffected(); err != nil {
 		err = fmt.Errorf("count row affected for INSERT IGNORE INTO `service_ids`: %w", err)
-		_ = tx.Rollback()
 		return
 	} else if rowsAffected > 0 {
 		isNew = true
 	}
 	if err = tx.QueryRowContext(ctx, querySearchID, svcname, clusterID).Scan(&svcID); err != nil {
 		err = fmt.Errorf("retrieve service id failed:%w", err)
-		_ = tx.Rollback()
 		return
 	}
-	err = tx.Commit()
 	return
 }
 
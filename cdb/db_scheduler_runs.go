@@ -0,0 +1,170 @@
+package cdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/opensvc/oc3/schema"
+)
+
+type (
+	/*
+		CREATE TABLE `oc3_scheduler_runs` (
+		 `id` bigint(20) NOT NULL AUTO_INCREMENT,
+		 `task_name` varchar(255) NOT NULL,
+		 `begin` datetime(6) NOT NULL,
+		 `end` datetime(6) NOT NULL,
+		 `status` varchar(16) NOT NULL,
+		 `error` text DEFAULT NULL,
+		 `rows_affected` bigint(20) DEFAULT NULL,
+		 PRIMARY KEY (`id`),
+		 KEY `k_task_name_begin` (`task_name`,`begin`),
+		 KEY `k_begin` (`begin`)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+	*/
+	SchedulerRun struct {
+		ID int64
+
+		// TaskName is the task name, "<parent>: <child>" for the children.
+		TaskName string
+
+		Begin  time.Time
+		End    time.Time
+		Status string
+		Error  string
+
+		// RowsAffected is the number of rows changed by the execution, or
+		// nil if unknown.
+		RowsAffected *int64
+	}
+
+	// SchedulerTaskFailing is the summary of a task whose executions fail
+	// since its last successful execution.
+	SchedulerTaskFailing struct {
+		TaskName string
+
+		// FailingSince is the begin of the first failed execution after
+		// the last successful execution.
+		FailingSince time.Time
+
+		// LastSuccess is the begin of the last successful execution, zero
+		// if the task never succeeded in the retained history.
+		LastSuccess time.Time
+
+		LastFailure time.Time
+		LastError   string
+		FailedRuns  int64
+	}
+)
+
+const (
+	// schedulerRunErrorMaxLen is the error text maximum length, the text
+	// column size.
+	schedulerRunErrorMaxLen = 65535
+)
+
+// InsertSchedulerRun saves a task execution to the scheduler runs history.
+func (oDb *DB) InsertSchedulerRun(ctx context.Context, run SchedulerRun) error {
+	const query = "INSERT INTO oc3_scheduler_runs (task_name, `begin`, `end`, status, error, rows_affected) VALUES (?, ?, ?, ?, ?, ?)"
+	var errText sql.NullString
+	if run.Error != "" {
+		errText.String, errText.Valid = run.Error, true
+		if len(errText.String) > schedulerRunErrorMaxLen {
+			errText.String = errText.String[:schedulerRunErrorMaxLen]
+		}
+	}
+	var rowsAffected sql.NullInt64
+	if run.RowsAffected != nil {
+		rowsAffected.Int64, rowsAffected.Valid = *run.RowsAffected, true
+	}
+	if _, err := oDb.DB.ExecContext(ctx, query, run.TaskName, run.Begin, run.End, run.Status, errText, rowsAffected); err != nil {
+		return fmt.Errorf("insertSchedulerRun: %w", err)
+	}
+	return nil
+}
+
+// buildSchedulerRunsQuery returns the scheduler runs query. The runs are
+// only visible to the managers.
+func buildSchedulerRunsQuery(isManager bool, selectExprs []string) (string, []any) {
+	q := From(schema.TOc3SchedulerRuns).
+		RawSelect(selectExprs...)
+
+	if !isManager {
+		q = q.WhereRaw("1=0")
+	} else {
+		q = q.Where(schema.Oc3SchedulerRunsID, ">", 0)
+	}
+
+	query, args, err := q.Build()
+	if err != nil {
+		panic(fmt.Sprintf("buildSchedulerRunsQuery: %v", err))
+	}
+	return query, args
+}
+
+// GetSchedulerRuns returns the scheduler runs history, the most recent
+// first by default.
+func (oDb *DB) GetSchedulerRuns(ctx context.Context, p ListParams) ([]map[string]any, error) {
+	query, args := buildSchedulerRunsQuery(p.IsManager, p.SelectExprs)
+	if gb := p.GroupByClause(""); gb != "" {
+		query += " " + gb
+	}
+	query += " " + p.OrderByClause("oc3_scheduler_runs.begin DESC, oc3_scheduler_runs.id DESC")
+	query, args = appendLimitOffset(query, args, p.Limit, p.Offset)
+
+	rows, err := oDb.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("getSchedulerRuns: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanRowsToMaps(rows, p.Props, p.TypeHints)
+}
+
+// SchedulerTasksFailing returns the tasks whose executions failed since
+// their last successful execution, and since at least minAge, the oldest
+// failure first.
+func (oDb *DB) SchedulerTasksFailing(ctx context.Context, minAge time.Duration) ([]SchedulerTaskFailing, error) {
+	const query = "SELECT f.task_name, f.failing_since, f.last_success, f.last_failure, f.failed_runs," +
+		" COALESCE((SELECT l.error FROM oc3_scheduler_runs l" +
+		"  WHERE l.task_name = f.task_name AND l.status = 'failed'" +
+		"  ORDER BY l.`begin` DESC, l.id DESC LIMIT 1), '')" +
+		" FROM (" +
+		"  SELECT r.task_name, MIN(r.`begin`) AS failing_since, s.last_success," +
+		"   MAX(r.`begin`) AS last_failure, COUNT(*) AS failed_runs" +
+		"  FROM oc3_scheduler_runs r" +
+		"  LEFT JOIN (" +
+		"   SELECT task_name, MAX(`begin`) AS last_success FROM oc3_scheduler_runs" +
+		"   WHERE status = 'ok' GROUP BY task_name" +
+		"  ) s ON s.task_name = r.task_name" +
+		"  WHERE r.status = 'failed' AND (s.last_success IS NULL OR r.`begin` > s.last_success)" +
+		"  GROUP BY r.task_name, s.last_success" +
+		" ) f" +
+		" WHERE f.failing_since <= ?" +
+		" ORDER BY f.failing_since, f.task_name"
+
+	rows, err := oDb.DB.QueryContext(ctx, query, time.Now().Add(-minAge))
+	if err != nil {
+		return nil, fmt.Errorf("schedulerTasksFailing: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var l []SchedulerTaskFailing
+	for rows.Next() {
+		var (
+			o           SchedulerTaskFailing
+			lastSuccess sql.NullTime
+		)
+		if err := rows.Scan(&o.TaskName, &o.FailingSince, &lastSuccess, &o.LastFailure, &o.FailedRuns, &o.LastError); err != nil {
+			return nil, fmt.Errorf("schedulerTasksFailing: scan: %w", err)
+		}
+		o.LastSuccess = lastSuccess.Time
+		l = append(l, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("schedulerTasksFailing: %w", err)
+	}
+	return l, nil
+}
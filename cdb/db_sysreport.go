@@ -0,0 +1,105 @@
+package cdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opensvc/oc3/schema"
+	"github.com/opensvc/oc3/util/git"
+)
+
+// SysreportLogInsert records a node sysreport commit in the sysreport_log table.
+//
+//	CREATE TABLE `sysreport_log` (
+//	 `id` bigint(20) NOT NULL AUTO_INCREMENT,
+//	 `node_id` char(36) CHARACTER SET ascii COLLATE ascii_general_ci NOT NULL,
+//	 `commit_id` char(40) CHARACTER SET ascii COLLATE ascii_general_ci NOT NULL,
+//	 `commit_date` datetime NOT NULL,
+//	 `message` text DEFAULT NULL,
+//	 `changes` int(11) DEFAULT 0,
+//	 `updated` timestamp NOT NULL DEFAULT current_timestamp(),
+//	 PRIMARY KEY (`id`),
+//	 UNIQUE KEY `k_node_commit` (`node_id`,`commit_id`),
+//	 KEY `k_node_commit_date` (`node_id`,`commit_date`)
+//	) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci
+func (oDb *DB) SysreportLogInsert(ctx context.Context, nodeID string, commit *git.CommitInfo) error {
+	defer logDuration("SysreportLogInsert", time.Now())
+	const query = "INSERT IGNORE INTO `sysreport_log` (`node_id`, `commit_id`, `commit_date`, `message`, `changes`)" +
+		" VALUES (?, ?, ?, ?, ?)"
+	if count, err := oDb.execCountContext(ctx, query, nodeID, commit.ID, commit.Date, commit.Message, len(commit.Changes)); err != nil {
+		return fmt.Errorf("SysreportLogInsert: %w", err)
+	} else if count > 0 {
+		oDb.SetChange("sysreport_log")
+	}
+	return nil
+}
+
+// GetNodeSysreportLog returns the sysreport commits of a node, most recent first.
+func (oDb *DB) GetNodeSysreportLog(ctx context.Context, nodeID string, p ListParams) ([]map[string]any, error) {
+	q := From(schema.TSysreportLog).
+		RawSelect(p.SelectExprs...).
+		Where(schema.SysreportLogNodeID, "=", nodeID)
+	q = applyNodeAppAuth(q, nodeID, p.Groups, p.IsManager)
+	query, args, err := q.Build()
+	if err != nil {
+		return nil, fmt.Errorf("GetNodeSysreportLog build: %w", err)
+	}
+	query += " " + p.OrderByClause("sysreport_log.commit_date DESC")
+	query, args = appendLimitOffset(query, args, p.Limit, p.Offset)
+	rows, err := oDb.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GetNodeSysreportLog: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRowsToMaps(rows, p.Props, p.TypeHints)
+}
+
+var (
+	// ErrAmbiguousCommitID is returned by SysreportCommitID when more than
+	// one node sysreport commit id starts with the prefix.
+	ErrAmbiguousCommitID = errors.New("ambiguous commit id")
+)
+
+// SysreportCommitID returns the full id of the node sysreport commit whose id
+// starts with prefix, or "" if the commit is not found or not visible from groups.
+// It returns an error wrapping ErrAmbiguousCommitID if prefix matches more
+// than one commit.
+func (oDb *DB) SysreportCommitID(ctx context.Context, nodeID, prefix string, groups []string, isManager bool) (string, error) {
+	q := From(schema.TSysreportLog).
+		Select(schema.SysreportLogCommitID).
+		Where(schema.SysreportLogNodeID, "=", nodeID).
+		WhereRaw("sysreport_log.commit_id LIKE ?", strings.ToLower(prefix)+"%")
+	q = applyNodeAppAuth(q, nodeID, groups, isManager)
+	query, args, err := q.Build()
+	if err != nil {
+		return "", fmt.Errorf("SysreportCommitID build: %w", err)
+	}
+	query += " LIMIT 2"
+	rows, err := oDb.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("SysreportCommitID: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", fmt.Errorf("SysreportCommitID scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("SysreportCommitID: %w", err)
+	}
+	switch len(ids) {
+	case 0:
+		return "", nil
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("SysreportCommitID %s: %w", prefix, ErrAmbiguousCommitID)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/opensvc/oc3/util/logkey"
 	"github.com/opensvc/oc3/util/version"
+	"github.com/opensvc/oc3/worker"
 )
 
 var (
@@ -66,6 +67,55 @@ func cmdWorker() *cobra.Command {
 	return cmd
 }
 
+func cmdWorkerQueues() *cobra.Command {
+	return &cobra.Command{
+		Use:   "queues",
+		Short: "list the known queue names",
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, name := range worker.QueueNames() {
+				fmt.Println(name)
+			}
+		},
+	}
+}
+
+func cmdWorkerReplay() *cobra.Command {
+	var (
+		jobType string
+		file    string
+		nodeID  string
+		index   string
+		dryRun  bool
+		redisDB int
+	)
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "run a job with a captured payload",
+		Long: "Run a job with a captured payload in a database transaction.\n\n" +
+			"The payload is stored in the redis database --redis-db during the run, so the\n" +
+			"live payloads are not overwritten. With --dry-run, this redis database must be\n" +
+			"empty and is flushed after the run, and the object ids are not allocated.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if index == "" {
+				index = nodeID
+			}
+			if index == "" {
+				return fmt.Errorf("--node-id or --index is required")
+			}
+			return workerReplay(jobType, file, index, dryRun, redisDB)
+		},
+	}
+	cmd.Flags().StringVar(&jobType, "type", "", "the job type, like daemonStatus")
+	cmd.Flags().StringVar(&file, "file", "", "the payload file")
+	cmd.Flags().StringVar(&nodeID, "node-id", "", "the node id")
+	cmd.Flags().StringVar(&index, "index", "", "the queue entry, for the jobs not indexed by node id, like <path>@<node id>@<cluster id>")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "roll back and print the sql statements and table changes")
+	cmd.Flags().IntVar(&redisDB, "redis-db", 15, "the scratch redis database where the payload is stored")
+	_ = cmd.MarkFlagRequired("type")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
 func cmdFeeder() *cobra.Command {
 	return &cobra.Command{
 		GroupID: GroupIDSubsystems,
@@ -158,6 +208,63 @@ func cmdSchedulerExec() *cobra.Command {
 	return cmd
 }
 
+// schedulerClientFlags are the flags of the commands calling the scheduler
+// api.
+type schedulerClientFlags struct {
+	url      string
+	user     string
+	password string
+}
+
+func (t *schedulerClientFlags) add(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&t.url, "url", "", "the scheduler api url, defaults to the scheduler.api.url config or http://<scheduler.addr>")
+	cmd.Flags().StringVar(&t.user, "user", "", "the manager user email, defaults to the scheduler.api.user config")
+	cmd.Flags().StringVar(&t.password, "password", "", "the manager user password, defaults to the scheduler.api.password config")
+}
+
+func (t *schedulerClientFlags) client() (*schedulerClient, error) {
+	return newSchedulerClient(t.url, t.user, t.password)
+}
+
+func cmdSchedulerStatus() *cobra.Command {
+	var flags schedulerClientFlags
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "show the tasks state, last run, last duration and last error from the running scheduler",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := flags.client()
+			if err != nil {
+				return err
+			}
+			return scheduleStatus(c)
+		},
+	}
+	flags.add(cmd)
+	return cmd
+}
+
+// cmdSchedulerAction returns the command posting action to a task of the
+// running scheduler.
+func cmdSchedulerAction(action, short string) *cobra.Command {
+	var flags schedulerClientFlags
+	var name string
+	cmd := &cobra.Command{
+		Use:   action,
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := flags.client()
+			if err != nil {
+				return err
+			}
+			return scheduleAction(c, name, action)
+		},
+	}
+	flags.add(cmd)
+	cmd.Flags().StringVar(&name, "name", "", "the task name")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
 func cmdVersion() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "version",
@@ -182,13 +289,23 @@ func Root(args []string) *cobra.Command {
 	grpScheduler.AddCommand(
 		cmdSchedulerExec(),
 		cmdSchedulerList(),
+		cmdSchedulerStatus(),
+		cmdSchedulerAction("enable", "enable a task on all the schedulers"),
+		cmdSchedulerAction("disable", "disable a task on all the schedulers"),
+		cmdSchedulerAction("trigger", "execute a task now on the running scheduler"),
+		cmdSchedulerAction("cancel", "cancel the running execution of a task or child task"),
+	)
+	grpWorker := cmdWorker()
+	grpWorker.AddCommand(
+		cmdWorkerQueues(),
+		cmdWorkerReplay(),
 	)
 	cmd.AddCommand(
 		cmdFeeder(),
 		cmdApiCollector(),
 		grpScheduler,
 		cmdVersion(),
-		cmdWorker(),
+		grpWorker,
 		cmdRunner(),
 		cmdMessenger(),
 	)
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/labstack/echo-contrib/echoprometheus"
 	"github.com/labstack/echo-contrib/pprof"
@@ -43,6 +46,12 @@ type (
 	}
 )
 
+// signalContext returns a context canceled on SIGINT or SIGTERM, so the
+// subsystems can stop gracefully.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
 func run(i Sectioner) error {
 	if ok, errC := start(i); ok {
 		slog.Info(fmt.Sprintf("%s started", i.Section()))
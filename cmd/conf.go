@@ -31,8 +31,13 @@ func setDefaultWorkerConfig(name string) {
 	viper.SetDefault(section+".pprof.ux.enable", false)
 	viper.SetDefault(section+".pprof.ux.socket", uxSocket)
 	viper.SetDefault(section+".metrics.enable", false)
+	viper.SetDefault(section+".metrics.queue_interval", "10s")
 
 	viper.SetDefault(section+".runners", 1)
+	viper.SetDefault(section+".max_attempts", 3)
+	viper.SetDefault(section+".heartbeat.interval", "5s")
+	viper.SetDefault(section+".heartbeat.timeout", "30s")
+	viper.SetDefault(section+".drain_timeout", "30s")
 	viper.SetDefault(section+".log.request.level", "none")
 }
 
@@ -48,6 +53,12 @@ func setDefaultFeederConfig() {
 	viper.SetDefault(s+".ui.enable", false)
 	viper.SetDefault(s+".sync.timeout", "2s")
 	viper.SetDefault(s+".log.request.level", "none")
+	viper.SetDefault(s+".sysreport.max_bytes", "100MB")
+	viper.SetDefault(s+".sysreport.max_entries", 10000)
+	viper.SetDefault(s+".body.max_bytes", "32MB")
+	viper.SetDefault(s+".body.max_bytes_by_operation.postnodesysreport", "128MB")
+	viper.SetDefault(s+".validation.mode", "off")
+	viper.SetDefault(s+".admission.interval", "5s")
 }
 
 func setDefaultServerConfig() {
@@ -73,6 +84,9 @@ func setDefaultSchedulerConfig() {
 	viper.SetDefault(s+".metrics.enable", false)
 	viper.SetDefault(s+".task.trim.retention", 365)
 	viper.SetDefault(s+".task.trim.batch_size", 1000)
+	viper.SetDefault(s+".task.alert_heartbeats_not_beating.threshold", "5m")
+	viper.SetDefault(s+".drain_timeout", "30s")
+	viper.SetDefault(s+".lease.enable", true)
 	viper.SetDefault(s+".log.request.level", "none")
 }
 
@@ -105,6 +119,7 @@ func setDefaultRunnerConfig() {
 	viper.SetDefault(s+".purge_timeout", 0)
 	viper.SetDefault(s+".notification_timeout", 0)
 	viper.SetDefault(s+".command_timeout", 0)
+	viper.SetDefault(s+".drain_timeout", 0)
 }
 
 func setDefaultDBConfig() {
@@ -122,6 +137,10 @@ func setDefaultRedisConfig() {
 	viper.SetDefault("redis.password", "")
 }
 
+func setDefaultDashboardConfig() {
+	viper.SetDefault("dashboard.severity_rules_interval", "1m")
+}
+
 func setDefaultAuthConfig() {
 	viper.SetDefault("w2p_hmac", "sha512:7755f108-1b83-45dc-8302-54be8f3616a1")
 }
@@ -135,6 +154,7 @@ func initConfig() error {
 	// defaults
 	setDefaultDBConfig()
 	setDefaultRedisConfig()
+	setDefaultDashboardConfig()
 	setDefaultFeederConfig()
 	setDefaultServerConfig()
 	setDefaultSchedulerConfig()
@@ -33,6 +33,9 @@ func newFeeder() (*feeder, error) {
 func (t *feeder) Section() string { return t.section }
 
 func (t *feeder) apiRegister(e *echo.Echo) {
+	e.Use(handlers.AdmissionMiddleware(context.Background(), pathApi, t.redis))
+	e.Use(handlers.BodyMiddleware(pathApi))
+	e.Use(handlers.ValidationMiddleware(pathApi))
 	api.RegisterHandlersWithBaseURL(e, &handlers.Api{
 		DB:          t.db,
 		Redis:       t.redis,
@@ -9,10 +9,15 @@ import (
 )
 
 func newRedis() *redis.Client {
+	return newRedisDB(viper.GetInt("Redis.Database"))
+}
+
+// newRedisDB returns a client of the redis database db
+func newRedisDB(db int) *redis.Client {
 	client := redis.NewClient(&redis.Options{
 		Addr:     viper.GetString("Redis.Address"),
 		Password: viper.GetString("Redis.Password"),
-		DB:       viper.GetInt("Redis.Database"),
+		DB:       db,
 	})
 	slog.Info(fmt.Sprintf("redis addr=%s", client.Options().Addr))
 	return client
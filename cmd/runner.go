@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
@@ -54,10 +53,13 @@ func startRunner() error {
 		}()
 	}
 
+	ctx, cancel := signalContext()
+	defer cancel()
+
 	ad := &runner.ActionDaemon{
 		DB:        t.db,
 		Ev:        newEv(),
-		Ctx:       context.Background(),
+		Ctx:       ctx,
 		SubSystem: t.Section(),
 	}
 
@@ -3,12 +3,21 @@ package cmd
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/labstack/echo/v4"
 	"github.com/shaj13/go-guardian/v2/auth/strategies/union"
+	"github.com/spf13/viper"
 
 	"github.com/opensvc/oc3/scheduler"
 	"github.com/opensvc/oc3/xauth"
@@ -19,15 +28,31 @@ type (
 		db      *sql.DB
 		redis   *redis.Client
 		section string
+
+		// sched is the scheduler controlled by the api.
+		sched *scheduler.Scheduler
+	}
+
+	// schedulerClient calls the scheduler api.
+	schedulerClient struct {
+		url      string
+		user     string
+		password string
+		client   *http.Client
 	}
 )
 
 func (t *schedulerT) authMiddleware(publicPath, publicPrefix []string) echo.MiddlewareFunc {
 	return AuthMiddleware(union.New(
 		xauth.NewPublicStrategy(publicPath, publicPrefix),
+		xauth.NewBasicWeb2py(t.db, viper.GetString("w2p_hmac")),
 	))
 }
 
+func (t *schedulerT) apiRegister(e *echo.Echo) {
+	t.sched.RegisterHandlersWithBaseURL(e, pathApi)
+}
+
 func newScheduler() (*schedulerT, error) {
 	if err := setup(sectionScheduler); err != nil {
 		return nil, err
@@ -57,6 +82,9 @@ func scheduleExec(name string) error {
 }
 
 func scheduleList() error {
+	if err := scheduler.Tasks.Validate(); err != nil {
+		return err
+	}
 	scheduler.Tasks.Print()
 	return nil
 }
@@ -66,6 +94,15 @@ func startScheduler() error {
 	if err != nil {
 		return err
 	}
+	t.sched = &scheduler.Scheduler{
+		DB:    t.db,
+		Redis: t.redis,
+		Ev:    newEv(),
+
+		DrainTimeout: viper.GetDuration(t.section + ".drain_timeout"),
+		ID:           viper.GetString(t.section + ".id"),
+		Lease:        viper.GetBool(t.section + ".lease.enable"),
+	}
 	if ok, errC := start(t); ok {
 		slog.Info(fmt.Sprintf("%s started", t.Section()))
 		go func() {
@@ -75,14 +112,106 @@ func startScheduler() error {
 		}()
 	}
 
-	sched := &scheduler.Scheduler{
-		DB:    t.db,
-		Redis: t.redis,
-		Ev:    newEv(),
-	}
-	return sched.Run()
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	return t.sched.Run(ctx)
 }
 
 func (t *schedulerT) Section() string {
 	return t.section
 }
+
+// newSchedulerClient returns a scheduler api client. The empty url, user
+// and password default to the scheduler.api.url, .user and .password
+// configuration.
+func newSchedulerClient(url, user, password string) (*schedulerClient, error) {
+	if err := initConfig(); err != nil {
+		return nil, err
+	}
+	t := &schedulerClient{
+		url:      url,
+		user:     user,
+		password: password,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+	if t.url == "" {
+		t.url = viper.GetString(sectionScheduler + ".api.url")
+	}
+	if t.url == "" {
+		t.url = "http://" + viper.GetString(sectionScheduler+".addr")
+	}
+	if t.user == "" {
+		t.user = viper.GetString(sectionScheduler + ".api.user")
+	}
+	if t.password == "" {
+		t.password = viper.GetString(sectionScheduler + ".api.password")
+	}
+	return t, nil
+}
+
+// do sends the request to the scheduler api path, and decodes the response
+// body into out.
+func (t *schedulerClient) do(method, path string, out any) error {
+	req, err := http.NewRequest(method, strings.TrimSuffix(t.url, "/")+pathApi+path, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(t.user, t.password)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var problem struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(b, &problem); err == nil && problem.Text != "" {
+			return fmt.Errorf("%s: %s", resp.Status, problem.Text)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return json.Unmarshal(b, out)
+}
+
+func scheduleStatus(c *schedulerClient) error {
+	var resp struct {
+		Data []scheduler.TaskInfo `json:"data"`
+	}
+	if err := c.do(http.MethodGet, "/tasks", &resp); err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSTATE\tLAST RUN\tDURATION\tSCHEDULE\tERROR")
+	var printTask func(info scheduler.TaskInfo, indent string)
+	printTask = func(info scheduler.TaskInfo, indent string) {
+		var lastRunAt string
+		if info.LastRunAt != nil {
+			lastRunAt = info.LastRunAt.Local().Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\t%s\t%s\n", indent, info.Name, info.State, lastRunAt, info.LastDuration, info.Schedule, info.LastError)
+		for _, child := range info.Children {
+			printTask(child, indent+"  ")
+		}
+	}
+	for _, info := range resp.Data {
+		printTask(info, "")
+	}
+	return w.Flush()
+}
+
+// scheduleAction posts the action, one of enable, disable, trigger and
+// cancel, to the task name.
+func scheduleAction(c *schedulerClient, name, action string) error {
+	var info scheduler.TaskInfo
+	if err := c.do(http.MethodPost, "/tasks/"+url.PathEscape(name)+"/"+action, &info); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %s\n", info.Name, info.State)
+	return nil
+}
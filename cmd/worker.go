@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
 	_ "net/http/pprof"
+	"os"
+	"path"
+	"slices"
+	"strings"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/labstack/echo/v4"
@@ -25,6 +30,22 @@ type (
 		section string
 		runners int
 		queues  []string
+
+		queueOptions map[string]worker.QueueOptions
+
+		severityRules []cdb.DashboardSeverityRule
+
+		statusMergeRules map[string][]worker.StatusMergeRule
+	}
+
+	// severityRuleConfig is an entry of the dashboard.severity_rules config
+	severityRuleConfig struct {
+		Priority      int    `mapstructure:"priority"`
+		DashType      string `mapstructure:"dash_type"`
+		App           string `mapstructure:"app"`
+		Env           string `mapstructure:"env"`
+		ObjectPattern string `mapstructure:"object_pattern"`
+		Severity      int    `mapstructure:"severity"`
 	}
 )
 
@@ -41,8 +62,51 @@ func newWorker(section string, runners int, queues []string) (*workerT, error) {
 		queues = viper.GetStringSlice(t.section + ".queues")
 	}
 	for _, q := range queues {
+		if !worker.IsQueueName(q) {
+			return nil, fmt.Errorf("unknown queue %q, expected one of: %s", q, strings.Join(worker.QueueNames(), ", "))
+		}
 		t.queues = append(t.queues, cachekeys.QueuePrefix+q)
 	}
+	t.queueOptions = make(map[string]worker.QueueOptions)
+	for q := range viper.GetStringMap(t.section + ".queue") {
+		if !worker.IsQueueName(q) {
+			return nil, fmt.Errorf("%s.queue.%s: unknown queue, expected one of: %s", t.section, q, strings.Join(worker.QueueNames(), ", "))
+		}
+		prefix := t.section + ".queue." + q
+		t.queueOptions[cachekeys.QueuePrefix+q] = worker.QueueOptions{
+			Concurrency: viper.GetInt(prefix + ".concurrency"),
+			Weight:      viper.GetInt(prefix + ".weight"),
+			MaxAge:      viper.GetDuration(prefix + ".max_age"),
+		}
+	}
+	var rules []severityRuleConfig
+	if err := viper.UnmarshalKey("dashboard.severity_rules", &rules); err != nil {
+		return nil, fmt.Errorf("dashboard.severity_rules: %w", err)
+	}
+	for i, rule := range rules {
+		if rule.Severity < 0 {
+			return nil, fmt.Errorf("dashboard.severity_rules[%d]: invalid severity %d", i, rule.Severity)
+		}
+		if _, err := path.Match(rule.ObjectPattern, ""); err != nil {
+			return nil, fmt.Errorf("dashboard.severity_rules[%d]: invalid object_pattern %s: %w", i, rule.ObjectPattern, err)
+		}
+		t.severityRules = append(t.severityRules, cdb.DashboardSeverityRule{
+			Priority:      rule.Priority,
+			DashType:      rule.DashType,
+			App:           rule.App,
+			Env:           rule.Env,
+			ObjectPattern: rule.ObjectPattern,
+			Severity:      rule.Severity,
+		})
+	}
+	if err := viper.UnmarshalKey("status_merge", &t.statusMergeRules); err != nil {
+		return nil, fmt.Errorf("status_merge: %w", err)
+	}
+	for kind := range t.statusMergeRules {
+		if !slices.Contains(worker.StatusMergeKinds(), kind) {
+			return nil, fmt.Errorf("status_merge.%s: unknown status kind, expected one of: %s", kind, strings.Join(worker.StatusMergeKinds(), ", "))
+		}
+	}
 	return t, nil
 }
 
@@ -82,6 +146,50 @@ func (t *workerT) run() error {
 		Runners:   t.runners,
 		SubSystem: t.Section(),
 		ODB:       odb,
+
+		QueueOptions: t.queueOptions,
+
+		MaxAttempts:       viper.GetInt(t.section + ".max_attempts"),
+		HeartbeatInterval: viper.GetDuration(t.section + ".heartbeat.interval"),
+		HeartbeatTimeout:  viper.GetDuration(t.section + ".heartbeat.timeout"),
+
+		CaptureDir:     viper.GetString(t.section + ".capture.directory"),
+		CaptureNodeIDs: viper.GetStringSlice(t.section + ".capture.node_ids"),
+
+		DrainTimeout: viper.GetDuration(t.section + ".drain_timeout"),
+
+		QueueMetricsInterval: viper.GetDuration(t.section + ".metrics.queue_interval"),
+
+		StatusMergeRules: t.statusMergeRules,
+
+		SeverityRules:         t.severityRules,
+		SeverityRulesInterval: viper.GetDuration("dashboard.severity_rules_interval"),
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	return w.Run(ctx)
+}
+
+func workerReplay(jobType, file, index string, dryRun bool, redisDB int) error {
+	if err := setup(sectionWorker); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	db, err := newDatabase()
+	if err != nil {
+		return err
+	}
+	replay := &worker.Replay{
+		DB:      db,
+		Redis:   newRedisDB(redisDB),
+		Type:    jobType,
+		Index:   index,
+		Payload: b,
+		DryRun:  dryRun,
+		Out:     os.Stdout,
 	}
-	return w.Run()
+	return replay.Run(context.Background())
 }
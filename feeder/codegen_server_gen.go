@@ -24,35 +24,41 @@ type ServerInterface interface {
 	// (POST /checks)
 	PostChecks(ctx echo.Context) error
 
+	// (POST /daemon/events)
+	PostDaemonEvents(ctx echo.Context, params PostDaemonEventsParams) error
+
 	// (POST /daemon/ping)
 	PostDaemonPing(ctx echo.Context) error
 
+	// (PATCH /daemon/status)
+	PatchDaemonStatus(ctx echo.Context, params PatchDaemonStatusParams) error
+
 	// (POST /daemon/status)
-	PostDaemonStatus(ctx echo.Context) error
+	PostDaemonStatus(ctx echo.Context, params PostDaemonStatusParams) error
 
 	// (POST /instance/action)
-	PostInstanceAction(ctx echo.Context) error
+	PostInstanceAction(ctx echo.Context, params PostInstanceActionParams) error
 
 	// (PUT /instance/action)
-	PutInstanceActionEnd(ctx echo.Context) error
+	PutInstanceActionEnd(ctx echo.Context, params PutInstanceActionEndParams) error
 
 	// (POST /instance/resource_info)
-	PostInstanceResourceInfo(ctx echo.Context) error
+	PostInstanceResourceInfo(ctx echo.Context, params PostInstanceResourceInfoParams) error
 
 	// (POST /instance/status)
 	PostInstanceStatus(ctx echo.Context, params PostInstanceStatusParams) error
 
 	// (POST /node/disk)
-	PostNodeDisk(ctx echo.Context) error
+	PostNodeDisk(ctx echo.Context, params PostNodeDiskParams) error
 
 	// (POST /node/sysreport)
 	PostNodeSysReport(ctx echo.Context) error
 
 	// (POST /node/system)
-	PostSystem(ctx echo.Context) error
+	PostSystem(ctx echo.Context, params PostSystemParams) error
 
 	// (POST /object/config)
-	PostObjectConfig(ctx echo.Context) error
+	PostObjectConfig(ctx echo.Context, params PostObjectConfigParams) error
 
 	// (GET /openapi.json)
 	GetSwagger(ctx echo.Context) error
@@ -79,6 +85,28 @@ func (w *ServerInterfaceWrapper) PostChecks(ctx echo.Context) error {
 	return err
 }
 
+// PostDaemonEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) PostDaemonEvents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostDaemonEventsParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostDaemonEvents(ctx, params)
+	return err
+}
+
 // PostDaemonPing converts echo context to params.
 func (w *ServerInterfaceWrapper) PostDaemonPing(ctx echo.Context) error {
 	var err error
@@ -92,6 +120,28 @@ func (w *ServerInterfaceWrapper) PostDaemonPing(ctx echo.Context) error {
 	return err
 }
 
+// PatchDaemonStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchDaemonStatus(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchDaemonStatusParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchDaemonStatus(ctx, params)
+	return err
+}
+
 // PostDaemonStatus converts echo context to params.
 func (w *ServerInterfaceWrapper) PostDaemonStatus(ctx echo.Context) error {
 	var err error
@@ -100,8 +150,17 @@ func (w *ServerInterfaceWrapper) PostDaemonStatus(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostDaemonStatusParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostDaemonStatus(ctx)
+	err = w.Handler.PostDaemonStatus(ctx, params)
 	return err
 }
 
@@ -113,8 +172,17 @@ func (w *ServerInterfaceWrapper) PostInstanceAction(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostInstanceActionParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostInstanceAction(ctx)
+	err = w.Handler.PostInstanceAction(ctx, params)
 	return err
 }
 
@@ -126,8 +194,17 @@ func (w *ServerInterfaceWrapper) PutInstanceActionEnd(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutInstanceActionEndParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PutInstanceActionEnd(ctx)
+	err = w.Handler.PutInstanceActionEnd(ctx, params)
 	return err
 }
 
@@ -139,8 +216,17 @@ func (w *ServerInterfaceWrapper) PostInstanceResourceInfo(ctx echo.Context) erro
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostInstanceResourceInfoParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostInstanceResourceInfo(ctx)
+	err = w.Handler.PostInstanceResourceInfo(ctx, params)
 	return err
 }
 
@@ -174,8 +260,17 @@ func (w *ServerInterfaceWrapper) PostNodeDisk(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNodeDiskParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostNodeDisk(ctx)
+	err = w.Handler.PostNodeDisk(ctx, params)
 	return err
 }
 
@@ -200,8 +295,17 @@ func (w *ServerInterfaceWrapper) PostSystem(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostSystemParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostSystem(ctx)
+	err = w.Handler.PostSystem(ctx, params)
 	return err
 }
 
@@ -213,8 +317,17 @@ func (w *ServerInterfaceWrapper) PostObjectConfig(ctx echo.Context) error {
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostObjectConfigParams
+	// ------------- Optional query parameter "sync" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sync", ctx.QueryParams(), &params.Sync)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sync: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostObjectConfig(ctx)
+	err = w.Handler.PostObjectConfig(ctx, params)
 	return err
 }
 
@@ -265,7 +378,9 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.POST(baseURL+"/checks", wrapper.PostChecks)
+	router.POST(baseURL+"/daemon/events", wrapper.PostDaemonEvents)
 	router.POST(baseURL+"/daemon/ping", wrapper.PostDaemonPing)
+	router.PATCH(baseURL+"/daemon/status", wrapper.PatchDaemonStatus)
 	router.POST(baseURL+"/daemon/status", wrapper.PostDaemonStatus)
 	router.POST(baseURL+"/instance/action", wrapper.PostInstanceAction)
 	router.PUT(baseURL+"/instance/action", wrapper.PutInstanceActionEnd)
@@ -283,40 +398,58 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaW2/bOBb+KwR3H2YBN06T7sP6Le20i+4A02w8s/uQCQyaOpY5lUiWpNx4Cv/3AW+6",
-	"2JTkXFwM0D4lJqlz+c7Hw6NDfcFUlFJw4Ebj2RcsiSIlGFDuF+P/rUBt51tO/U88w5/sCJ5gTkrAM6zt",
-	"3ARruoaS2EVmK+34UogCCMe73W6CFWgpuAYn9NX5uf1DBTfAjf2XSFkwSgwTfPq7FtyONQL/rmCFZ/hv",
-	"08bSqZ/V02sllgWUXksGmiomrRg8w69Jhm7gUwXa4N0Evzp/+TW0/spJZdZCsT8g82ovv4bad0ItWZYB",
-	"tzr/+XUAfs8NKE4KNAe1AYXeKiWU1f8jgVLwa8bzK0pBGsgeZI5UQoIyzLNFLH8HahafmVmLyiyo4CuW",
-	"24muMQXTBokV8suRJadGZk0MUvCpYgo0uv4w/wVNiWRTv2gaZE0wM1DqQ5ktWXgSea2NYjy3boYBohTZ",
-	"4l0z4B9LIZY5YJA2xFQaGVaCNqSUGn1mRYGWgBSsFOg1ZGglFOIigwbPuXvqO6IDiA7BuIs5ypl1Rb2A",
-	"fWxIPX5gHFH5xiXB6NqI+RO8hJzxQxDcMPKaGhIgxtHNuzeXl5f/+plwYS0viUmhRFXHwjrRTjDw7FAd",
-	"8OwJyiQx66SzGrRmgi+qimXpBS4mA1OLQuTJ6V6RG1A6hKfro1kDEhK43lBECwbcoIwYguIDB465M8mx",
-	"OMOzW+/lJEa/URRjGEDf8zpwIhjs4Y9CCpGvWGFZHnC4O2DzJLAwHFLtnd0lZQ8eez64VSktPzL98VBo",
-	"lsa+B/lSZFAkZ0I26eWJgrxvR2n2B9iJwL4ZZtxcXjTBYtxADu5IqTS0DWvNbIBnQo3D4yLUNjboD7Jr",
-	"QdHXiUUoBed7rg3hFG5Ai0pReM9X4hBeFkbrbNGd/ghbPTyd3gCkqGDcV/t4XJxyYT9RqWP4pRyCzvBj",
-	"ZPYSwggpCpFvxzWGMDkohyIxrzPNHsWJaVekzYO9tp06wTiLGjUpp34WGdgdO+BPTZqhws3t+iPO1An+",
-	"4P57U5cBeyeilEmoqCjLUHwczGVKLtypOzSpH3aYAt8k160KuF+U5D6dHfws4wOzhqgcTHpBKTgzQkG2",
-	"UGG3L6ioeM9qoegatFHEpD3vz5Hkc6sMq9PhcmuSZZKmQsLD0HvgpksR81po82YNNMXMDSm64Yz/pEzZ",
-	"+70h6kFU2LPXPT7xFvSZ3byQHJpeUzFd/dKi0gZCEdlb17ZXHVndxsPoCN3tCvyZausTpjoPaOPecLpr",
-	"wtOXxema8HwvWyQ9FwqxcCIcA0D/6aBgw0SlF5XMiIFsQUxnW9rBF7aUTml5zDMnC0U4biKCI5EIb/oH",
-	"ATBwbw5tu0LrqiT8hQKSkWUBCO5lQbh7BUVaAmUrRpERyKyZRoLSSingFCyxzRp+49LrO/tt3A9nQcrm",
-	"+VbfgBTKJA5LKCBU08enSVeyd1Iw48R1u0aADsqChJSpktCPJIfEAato+lRwZC6KB5LJt+WS1XY+gMFw",
-	"MTSy433KaVjpXAqSveIUInqrTYpvLaSOqnTi+qOKnZZTe0dYMwH3pJSWCPj87Pzs5Wj0+3eVe0+mlWJm",
-	"O7fWelVLohm9qnwx4Lxw7/F2tNG1Nkb6JgJRoOJq/+tdZMJ//v9L7L06EW52X8ZuN6lfRwwzzrGYVVYA",
-	"GShEJGsFcIYvnd/2jJLA7aQfOse+iHFeTGlTDAidyBDXlV4jv8jtcYu2Sw/vMzvblBMeTdDmtci2z9e5",
-	"bBTsuhEzqoL9pvSF75l2PfjwUyeEeHbbCd7t3W7ypROg27udJQHJteVFAOjOypj6TtVUxiIkCdmNb1zV",
-	"J39fw7AHz1adczpMW0qOwvWiT2i9bppoGO8m+OL81SFCJdOa8XwPmdjmm4SWZAA7zDKNaiOfEk+SWwA7",
-	"4WyaXI8I6GAUQzl06jgGNc8byb1WtbsGOR9/2C5qbmrG1r5sXa+Mrb1sXYsMr7WLnosjsRqdNl3lNEte",
-	"+45w3RS2dCYoZxvgsfi3abeHL7ENchW7l6dgTBB+PE+eUet+gzRxDxCAi631b5h4EyyrBMXe8uxxBKv2",
-	"+PXWNbn/EhRLUsBfd3zPPD7z1G2rWACmE9Cv7q3VvkFTBfa/KABFAcgKGElAnY74aTiSVPVYxqSdtKW1",
-	"JdLBZeLzR2esepgboZoOwMXZyyYuobSxb/hnI2GpK4n2tx23aZybJdP2tx/Wk1OG8yFVyPlAIAMq2vVs",
-	"ka4oBa1XVVFs0Q96y+laCS4q/Q9fXl6MS2pukmNOQT+QfUnfZIqx5fY0i1eLx2UV1x61z2jk292Vp2wP",
-	"geNVyIlySXPT8tgE0ufPCXOHg11vtWraXumXb6GNx7tePIBy00cbgrqsCsMkUWZqs+SL2EA9Du1GxWPR",
-	"rh35fr5HFsT+WT8F/CJLUio2oLY9JJh7WafZaMHQJ8TduUAMGflQ6pvkQvfTq+OTcaj7j0nEnRvi03Ck",
-	"o+LJBd3XysahPXoWfQ0XyV0E/w1m/pnkuevMpoqZowEc/Rzuw09/GWpHuGS1LBgNeLWa7AGqrv0KTKU4",
-	"IpK17rgO0PxfPfUkNIfoGLX3YvwoLCiRZMkK5u4c7naehWoTC/JKFXiGz6Z4d7f7MwAA//8uCkmKmC0A",
-	"AA==",
+	"H4sIAAAAAAAC/+wcXVcbN/av6MzuQ3vOBDuQdje8UZps024bFujuQ+BwZM21rTIjTSSNwc3xf9+jr/nU",
+	"jAcHKGl5AktX90v3S9K1P0WEZzlnwJSMDj9FORY4AwXCfKLsPwWI9dmaEf0xAUkEzRXlLDqMbjBVaM4F",
+	"UktAN1xcg0CKI5zn6dqMJVjhWP/HEGbyBgTan05jxAXCKBd8lkKG6Ly+fI5pCkkXyx46XwLan+4jqbAq",
+	"JKISCVCFYJC0UCyxRIwrg4BCUqJAM5hzAebzHCABgeSaEaRoBrxQe1EcUS3VRy1wFEcMZxAdRhomiiNJ",
+	"lpBhrQO1zvX4jPMUMIs2m00cCZA5ZxKMzl5Np/oP4UwBU/pfwwrBWm2T36TW3acawr8LmEeH0d8m1UZM",
+	"7KycnFgtWSpN7X+HE3QKHwuQKtrE0avpy8eg+ivDhVpyQX+HxJI9eAyyb7mY0SQBZmm+fgyax5zNU0qs",
+	"dl8ePBJJjR+dc47+jcUCLO1vHmdnZZHnXChI0M+QUIzO17llYP9R9K2F/hmztbdqGcXREnDiQtEpKLF+",
+	"cTRXILqhSDs1K7IZCMTnSALhLJE6jJgY5TxfaAyULULuTJmCBQjN1yaOvnkcF37HFAiGU3QGYgUCvRGC",
+	"i8jQfxRz02QpAfQrwytMUzxL4Y/T+fcYMs7erDTzR4RAriC5kxJywXMQitoozGe/AVFXN1QteaGuCGdz",
+	"uugKkVKpNPsWHOmgL5FaYoUEfCyoAIlO3p+downO6cQCTRyuOKIKMtnFWcMVxV5YqYRWw6YcwELgdbSp",
+	"Buyy0D4lRjUIjG4QFlAlN27yGUkLqUC45BgjzJLAOLqhaYpmelPmAuQSEpO9GU/AJGUqazOUmfS4FJzx",
+	"QqKMJxCVu3RC2eJ5j0J75DStiwqpcJYPKb3S55lZdQ8aXQADgS1LIX+t5rVC9Qh2RFFDgFhbViEBYYlm",
+	"WIKHZnCr0MnR+fEPaGIXTOyCKI7mXGRYWb/+9lUUd9w8/pI23FFKosMPda1e3tkUtjudd+agy2184DQy",
+	"HRG/t819x+V4RzIsFis9Uepli+xxNIMFDdiPGUaWUmXgmuvTt8cHBwevf8GMI2cFARUTwVmogI4jYEmX",
+	"HLDkM4jlWC2DwkqQknJ2VRQ0CQNYe+6fukr5Ijjdi3IFQva6JM+ByRVBJKXAlD2q+AUdwVqGaaSM/e5X",
+	"hPweOqW3pHY24Ri26vdIUr6YU1MGOD10DT52VujKtHrUahpljz5aMhioEJVaQdDFrdXUU5LADVrhtIAY",
+	"pfTanvfMZx/DJM4AXcNaG5P+bENJI5qh1YHZiCjA1jW11gqsyDT/xzbHHpv482ueYK2LOPqFJ2DDenPs",
+	"Z86o4qIa/AGwUDPAqhp6x6TCjHTW+/EODj/RZsKPfw8p2JH3Ro42YjvqoS4D/mTyVVjfPAETVmMfnRM0",
+	"WyM/FaOlF9CUJdSx5KqZId/t0nJBXAN0qZWYNRkH2UekZYNmT4M2SOV1wPjC/t/j/TqOp8EZlw57Y5WA",
+	"RV9Ul/R3sx/1pHuwH0y6hYQkVHXrcMESLra7qIkSdWYdfYe7RORljbWGQur09ngKkheCwDs25131Ujda",
+	"Zqzm9DWs5fB0OAjrGLBdVr3cA4dEaCdLMSbGCaNBw/gYnL0GoXjOU75Yb6fotsmocmgnzspsF46vnYW9",
+	"vD10knPx2K8KCaXjq/bYAXlKoxk6MmscY04BPnAel3VskyjO86CqCM8yl9U6c4nIr3yw7Z2UdyvogK2C",
+	"cPMUbq8yfBuODnaWsoFZpe+oVBggs0kKkivhvP2K8IL1QHNBliCVwCoseX+MxDe1c0QZDmdrFazzJeE5",
+	"3E17d3S6kGGeYEWW9aNe11bIErNFa2ODJxh9ZnDOu/0gE+9wImydZJeg8y1ZumOKRIqPO+yZVWGiP569",
+	"/wVlIBYed3Xhz6sL+xA/oYosF7CivJBXhS1orrBq2IIefKHPECF97bLmwUJdbbO8/uLSMoZj3wmX6ngJ",
+	"JBT8VjhtGpb/J2Ttrc8rLO4UbVoSmeWx5aCP7frNX5d5KMfHxe4KWVCch9q6stLctknVDVpX1jK2h+9D",
+	"/HWeBuu/6ahDjbzv8NXdCNr1O5l7um15wF2xCq3EG7s9jx2l+8utP01sc/XbyGjmHi06G6DgVnV5O0LL",
+	"IsPshQCc6FcMBLd5iplNazIHQueU2NxCJeKEFEKAPiraFHPB3Gv03sV2OQwHIZ7P1vIUci5CtxXuZH2n",
+	"usPcwzRqGsqweZreoujysG8wDLLaq2cBGhYC93L6Id7PIizIkq4AAVNC1wZcIEfeHNIbEWIoapcMnTrM",
+	"b5gSwXz0RAwgrjQ0qOCmPN2Ab5oMgkd/LINH/3aAsxHWgYdYyTG5xgvo0tZ7F6RtAlea3jFw9Ioi6WLA",
+	"3odPkqOEryKQEclhtoRDGpFrqUI2X9PUKKP18KNOijWhWsVZNQG3OMu100fTveney62W2B9BtZRACkHV",
+	"+kxza0nNsKTkqLAnKSOFXmNGK1pLpXL7CoAFCA9tP731lvDj/879i65BYWbbODabuLzLUVQZwXwGcR04",
+	"OKe1DTyMDozcm9jA6Uk7NLXl8NJIMSFVmctlIBicFHKJLJBxZ61tEwneJdFhvVC22gSpvuPJ+v4e3CsC",
+	"m+aOKVFAu1tofzrtSvD+p1rTSYhWiWKigaomkW2w39T6ObbA7r+udSIMw2qguslFhx8axvbhchN/ahjU",
+	"h8uNNlq8kNqO3YZeahz+Ir4q+8ObfGSOixjNzPmxVqA2HstjfcnPRQIibr2VXzB3wM0wZQpTVl0kE56m",
+	"QBQXaC54ZoZ8GebQmna0C2Y/IAF5iol9achBUJ5QEnxV8E+uC73sgkFGlaJsgbBjF0klAGd76Ai9mr4O",
+	"tbrd6F46f7V+wbzM1La8rUGha8ZvmGu6s+KaUi0rpArxtNfjIY0zWdzoCvwQNocKZFLvGtxcWg94CC9r",
+	"MDne14aNOdiHsomj/en+7otfjaGsgapOvm2wL2vtd9tgD2ptc9tgXz+d0DMdE3qmjxamjN82o1Tuj/HB",
+	"GHVqn/zboanTIzLogye2e+phfejElhcjPGi0EzR6hIz/vOpqKKNS6gjY1EzVIBGKoiYeOib/SlkyYH5V",
+	"r0LPZeuwBeo+HIS7t7Ffnb49Rv84+Oe3XyM+v2DtC9kUS9XbN0QTYIrOqU2nVOe56lZzTGYLYS+TNZXI",
+	"mwy3rd6YcbUEUbvHDie/CzY2+3Xu6p9k+utweb/5r9WRdrf81138nP/+RPkv3iXdDea4p+xobSaf/Wyb",
+	"nz37znDy9g8Dk6prM+xQ39mOy7Lpcm6+ObWgK2D1Vqge1/ItHkekfFB8as7lWBvvUvdItd2+GGjkdWq3",
+	"ja/SNDMgWRACUs6LNF2jr2r9ul/XnPcPZBM/R4MvKpMWAb9/o1ufd/H6ouX0b0xn75/A74MGDyy5k1f2",
+	"Inn2mS81g5atZf6dIZxIbaezPjISAfo/jwB5BEgj2JJIG12rT9Gtgozu6mRhFen3H/OmurPfDePtfFvm",
+	"2R/u4g+1C6GgI5zpbSvbOPb3XlaeYJfab9tvcYQnfFxrsfjZxu+0cg/m7jBVXwMr77i+wm1Mz0noC3A6",
+	"xhOYJP4LIuPyjl6D9BqJbNNyYV2sx+F8Q/uTdLWq235XL+vTxme4Wy/K58Sys43LtRRVY1u45YJLZY27",
+	"BB4w6apTbsgysyJVNMdCTXR58MK3SI4zzorE+FetpkilIKHjwb04UKcLb/hXXmLbPOA67tzP7vhuO9Om",
+	"q99DCibxvGzIe4QE8Wiq6Pw4ilXIjCdrBLcEILHfVsjwLc2KDOmvqHk1ea3V4aoN/lhwheVf3sl9U1y/",
+	"h1sgHV8JX4FY9/j4mcX1FJOWE3PXjOUVoF9Dd09SdSzDPw/xXAh+Af7T/AWK8cWgXTeqEGx8z/Ap+lWD",
+	"wc8+dd1XNdiD8LkW3MnMbUvunjcM983PprH+C9TZDV4sQESf+YKz9WdW3v/0kFFv/FZsKnXlxSylxOmr",
+	"1tjtVNXk37a+6Bbo2ndoOtr8bzn1YO9hnnqvjnfSBcE5ntGUmj73y421QrHyMasQaXQY7U2izeXm/wMA",
+	"0O5i6oRSAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
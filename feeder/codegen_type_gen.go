@@ -14,6 +14,20 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for DaemonEventKind.
+const (
+	ClusterConfigUpdated   DaemonEventKind = "ClusterConfigUpdated"
+	HeartbeatUpdated       DaemonEventKind = "HeartbeatUpdated"
+	InstanceConfigUpdated  DaemonEventKind = "InstanceConfigUpdated"
+	InstanceDeleted        DaemonEventKind = "InstanceDeleted"
+	InstanceMonitorUpdated DaemonEventKind = "InstanceMonitorUpdated"
+	InstanceStatusUpdated  DaemonEventKind = "InstanceStatusUpdated"
+	NodeMonitorUpdated     DaemonEventKind = "NodeMonitorUpdated"
+	NodeStatusUpdated      DaemonEventKind = "NodeStatusUpdated"
+	ObjectDeleted          DaemonEventKind = "ObjectDeleted"
+	ObjectStatusUpdated    DaemonEventKind = "ObjectStatusUpdated"
+)
+
 // Action defines model for Action.
 type Action struct {
 	Action string   `json:"action"`
@@ -40,6 +54,22 @@ type ActionRequestAccepted struct {
 	Uuid string `json:"uuid"`
 }
 
+// DaemonEvent defines model for DaemonEvent.
+type DaemonEvent struct {
+	// Data the new value, like the value of the same key in the POST /daemon/status v3 data
+	Data *map[string]interface{} `json:"data,omitempty"`
+	Kind DaemonEventKind         `json:"kind"`
+
+	// Node the node name, required by the node, heartbeat and instance events
+	Node *string `json:"node,omitempty"`
+
+	// Path the object path, required by the instance and object events
+	Path *string `json:"path,omitempty"`
+}
+
+// DaemonEventKind defines model for DaemonEvent.Kind.
+type DaemonEventKind string
+
 // Disk defines model for Disk.
 type Disk struct {
 	Dg         string  `json:"dg"`
@@ -97,12 +127,36 @@ type ObjectConfig struct {
 	Topology               *string   `json:"topology,omitempty"`
 }
 
+// PatchDaemonStatus defines model for PatchDaemonStatus.
+type PatchDaemonStatus struct {
+	Changes []string `json:"changes"`
+
+	// Generation the generation of the daemon status the patch applies to
+	Generation int64 `json:"generation"`
+
+	// Patch the JSON merge patch to apply to the data of the daemon status
+	Patch             map[string]interface{} `json:"patch"`
+	PreviousUpdatedAt *time.Time             `json:"previous_updated_at,omitempty"`
+	UpdatedAt         *time.Time             `json:"updated_at,omitempty"`
+
+	// Version the opensvc client data version
+	Version string `json:"version"`
+}
+
 // PostChecks defines model for PostChecks.
 type PostChecks struct {
 	Vals [][]interface{} `json:"vals"`
 	Vars []string        `json:"vars"`
 }
 
+// PostDaemonEvents defines model for PostDaemonEvents.
+type PostDaemonEvents struct {
+	Events []DaemonEvent `json:"events"`
+
+	// Version the opensvc client data version
+	Version string `json:"version"`
+}
+
 // PostDaemonPing defines model for PostDaemonPing.
 type PostDaemonPing struct {
 	// Nodes list of cluster node
@@ -139,6 +193,22 @@ type SysReport struct {
 	File    openapi_types.File `json:"file"`
 }
 
+// SysReportProblem defines model for SysReportProblem.
+type SysReportProblem struct {
+	// Rejected The rejected archive entries or deleted paths
+	Rejected []SysReportRejectedEntry `json:"rejected"`
+
+	// Text A human-readable explanation specific to this occurrence of the
+	// problem.
+	Text string `json:"text"`
+}
+
+// SysReportRejectedEntry defines model for SysReportRejectedEntry.
+type SysReportRejectedEntry struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
 // Package defines model for package.
 type Package struct {
 	Arch        string     `json:"arch"`
@@ -171,9 +241,30 @@ type N401 = Problem
 // N403 defines model for 403.
 type N403 = Problem
 
+// N409 defines model for 409.
+type N409 = Problem
+
+// N413 defines model for 413.
+type N413 = Problem
+
+// N415 defines model for 415.
+type N415 = Problem
+
+// N429 defines model for 429.
+type N429 = Problem
+
 // N500 defines model for 500.
 type N500 = Problem
 
+// N503 defines model for 503.
+type N503 = Problem
+
+// DaemonEventsAccepted defines model for DaemonEventsAccepted.
+type DaemonEventsAccepted struct {
+	// ObjectWithoutConfig list of object names that requires POST /api/object/config
+	ObjectWithoutConfig *[]string `json:"object_without_config,omitempty"`
+}
+
 // DaemonPingAccepted defines model for DaemonPingAccepted.
 type DaemonPingAccepted struct {
 	// ObjectWithoutConfig list of object names that requires POST /api/object/config
@@ -182,21 +273,85 @@ type DaemonPingAccepted struct {
 
 // DaemonStatusAccepted defines model for DaemonStatusAccepted.
 type DaemonStatusAccepted struct {
+	// Generation the generation of the accepted daemon status, to use as base of the next PATCH /daemon/status
+	Generation int64 `json:"generation"`
+
 	// ObjectWithoutConfig list of object names that requires POST /api/object/config
 	ObjectWithoutConfig *[]string `json:"object_without_config,omitempty"`
 }
 
+// PostDaemonEventsParams defines parameters for PostDaemonEvents.
+type PostDaemonEventsParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PatchDaemonStatusParams defines parameters for PatchDaemonStatus.
+type PatchDaemonStatusParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PostDaemonStatusParams defines parameters for PostDaemonStatus.
+type PostDaemonStatusParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PostInstanceActionParams defines parameters for PostInstanceAction.
+type PostInstanceActionParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PutInstanceActionEndParams defines parameters for PutInstanceActionEnd.
+type PutInstanceActionEndParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PostInstanceResourceInfoParams defines parameters for PostInstanceResourceInfo.
+type PostInstanceResourceInfoParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
 // PostInstanceStatusParams defines parameters for PostInstanceStatus.
 type PostInstanceStatusParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PostNodeDiskParams defines parameters for PostNodeDisk.
+type PostNodeDiskParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PostSystemParams defines parameters for PostSystem.
+type PostSystemParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
+	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
+}
+
+// PostObjectConfigParams defines parameters for PostObjectConfig.
+type PostObjectConfigParams struct {
+	// Sync wait for the worker to apply the data, then answer 200, or a problem if the worker failed to apply the data. The 202 status is returned if the worker has not applied the data before the feeder sync timeout.
 	Sync *InQuerySync `form:"sync,omitempty" json:"sync,omitempty"`
 }
 
 // PostChecksJSONRequestBody defines body for PostChecks for application/json ContentType.
 type PostChecksJSONRequestBody = PostChecks
 
+// PostDaemonEventsJSONRequestBody defines body for PostDaemonEvents for application/json ContentType.
+type PostDaemonEventsJSONRequestBody = PostDaemonEvents
+
 // PostDaemonPingJSONRequestBody defines body for PostDaemonPing for application/json ContentType.
 type PostDaemonPingJSONRequestBody = PostDaemonPing
 
+// PatchDaemonStatusJSONRequestBody defines body for PatchDaemonStatus for application/json ContentType.
+type PatchDaemonStatusJSONRequestBody = PatchDaemonStatus
+
 // PostDaemonStatusJSONRequestBody defines body for PostDaemonStatus for application/json ContentType.
 type PostDaemonStatusJSONRequestBody = PostDaemonStatus
 
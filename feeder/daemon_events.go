@@ -0,0 +1,24 @@
+package feeder
+
+import (
+	"strings"
+
+	"github.com/opensvc/oc3/cachekeys"
+)
+
+// DaemonEventsNodeKey returns the key of the set indexing the fields of a
+// node in the clusterID cluster daemon status hash, and true, if field is a
+// node field, "node <nodename> ...". The feeder and the worker update the
+// set with the hash, so the worker can replace the fields of a node without
+// walking the whole cluster hash.
+func DaemonEventsNodeKey(clusterID, field string) (string, bool) {
+	rest, ok := strings.CutPrefix(field, "node ")
+	if !ok {
+		return "", false
+	}
+	nodename, _, ok := strings.Cut(rest, " ")
+	if !ok || nodename == "" {
+		return "", false
+	}
+	return cachekeys.FeedDaemonEventsNodeS + clusterID + ":" + nodename, true
+}
@@ -0,0 +1,67 @@
+package feederhandlers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// bodyPostedBytes is the size of the request bodies, as posted
+	bodyPostedBytes = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "oc3",
+			Subsystem: "feeder",
+			Name:      "request_body_posted_bytes",
+			Help:      "Size of the feed request bodies as posted, before content decoding (operation={operationId}, encoding={|gzip|zstd})",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 10),
+		},
+		[]string{"operation", "encoding"},
+	)
+
+	// bodyDecodedBytes is the size of the request bodies, after content decoding
+	bodyDecodedBytes = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "oc3",
+			Subsystem: "feeder",
+			Name:      "request_body_decoded_bytes",
+			Help:      "Size of the feed request bodies after content decoding (operation={operationId}, encoding={|gzip|zstd})",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 10),
+		},
+		[]string{"operation", "encoding"},
+	)
+
+	// validationRejects is the number of invalid request fields detected by
+	// the validation middleware
+	validationRejects = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "oc3",
+			Subsystem: "feeder",
+			Name:      "request_validation_rejects_total",
+			Help:      "Number of invalid request fields detected by the validation middleware, in warn or strict mode (operation={operationId}, field={body.<path>|<location>.<parameter>})",
+		},
+		[]string{"operation", "field"},
+	)
+
+	// admissionRejects is the number of requests refused by the admission
+	// middleware
+	admissionRejects = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "oc3",
+			Subsystem: "feeder",
+			Name:      "admission_rejects_total",
+			Help:      "Number of requests refused by the admission middleware (operation={operationId}, reason={rate_limit|degraded})",
+		},
+		[]string{"operation", "reason"},
+	)
+
+	// admissionQueueLength is the sum of the feed queues length, as seen by
+	// the admission middleware
+	admissionQueueLength = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "oc3",
+			Subsystem: "feeder",
+			Name:      "admission_queue_length",
+			Help:      "Sum of the feed queues length, as seen by the admission middleware",
+		},
+	)
+)
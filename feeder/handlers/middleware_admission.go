@@ -0,0 +1,209 @@
+package feederhandlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+type (
+	// tokenBucket is the budget of an operation for each node: rate tokens
+	// per second, up to burst tokens.
+	tokenBucket struct {
+		rate  float64
+		burst float64
+	}
+
+	// queueLengthWatcher periodically sums the length of the feed queues.
+	// The interval is not modified once the watcher runs.
+	queueLengthWatcher struct {
+		redis    *redis.Client
+		interval time.Duration
+		length   atomic.Int64
+	}
+)
+
+var (
+	// tokenBucketScript takes a token from the KEYS[1] token bucket, refilled
+	// at ARGV[1] tokens per second up to ARGV[2] tokens. The redis server
+	// clock is used, so the feeder replicas share the same time reference.
+	// It returns {1, 0} if a token is taken, else {0, <ms to wait for a token>}.
+	tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+local v = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(v[1]) or burst
+local ts = tonumber(v[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate * 1000)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, wait}
+`)
+)
+
+// AdmissionMiddleware returns a middleware that throttles the node requests
+// with 429 when the node exceeds the operation budget, and sheds the
+// requests with 503 when the feeder is in degraded mode for the operation.
+//
+// The operation budgets are token buckets defined by the
+// feeder.rate_limit.<operationId>.rate (tokens per second) and
+// feeder.rate_limit.<operationId>.burst configs. The token buckets are stored
+// in redis, so they are shared by the feeder replicas. The operations without
+// budget are not throttled.
+//
+// The feeder is in degraded mode for an operation when the sum of the feed
+// queues length exceeds feeder.admission.queue_length_by_operation.<operationId>.
+// Give the less important operations, like PostDaemonPing, a lower
+// threshold so they are shed first.
+func AdmissionMiddleware(ctx context.Context, prefix string, r *redis.Client) echo.MiddlewareFunc {
+	operationIDs := make(map[string]string)
+	for k, route := range specRoutes(prefix) {
+		operationIDs[k] = route.Operation.OperationID
+	}
+	buckets := make(map[string]tokenBucket)
+	for k := range viper.GetStringMap("feeder.rate_limit") {
+		bucket := tokenBucket{
+			rate:  viper.GetFloat64("feeder.rate_limit." + k + ".rate"),
+			burst: viper.GetFloat64("feeder.rate_limit." + k + ".burst"),
+		}
+		if bucket.rate <= 0 {
+			slog.Warn(fmt.Sprintf("ignore feeder.rate_limit.%s: rate must be positive", k))
+			continue
+		}
+		if bucket.burst < 1 {
+			bucket.burst = 1
+		}
+		buckets[k] = bucket
+	}
+	maxQueueLength := make(map[string]int64)
+	for k := range viper.GetStringMap("feeder.admission.queue_length_by_operation") {
+		maxQueueLength[k] = viper.GetInt64("feeder.admission.queue_length_by_operation." + k)
+	}
+	var watcher *queueLengthWatcher
+	if len(maxQueueLength) > 0 {
+		watcher = newQueueLengthWatcher(r, viper.GetDuration("feeder.admission.interval"))
+		go watcher.run(ctx)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			operationID, ok := operationIDs[req.Method+" "+c.Path()]
+			if !ok {
+				return next(c)
+			}
+			k := strings.ToLower(operationID)
+
+			if max, ok := maxQueueLength[k]; ok && max > 0 {
+				if length := watcher.length.Load(); length > max {
+					admissionRejects.With(prometheus.Labels{"operation": operationID, "reason": "degraded"}).Inc()
+					c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(watcher.interval.Seconds())+1))
+					return JSONProblemf(c, http.StatusServiceUnavailable, "degraded mode: %d queued feed jobs", length)
+				}
+			}
+
+			nodeID := nodeIDFromContext(c)
+			bucket, ok := buckets[k]
+			if !ok || nodeID == "" {
+				return next(c)
+			}
+			key := cachekeys.FeederRateLimitH + operationID + ":" + nodeID
+			args := []any{strconv.FormatFloat(bucket.rate, 'f', -1, 64), strconv.FormatFloat(bucket.burst, 'f', -1, 64)}
+			result, err := tokenBucketScript.Run(req.Context(), r, []string{key}, args...).Int64Slice()
+			if err != nil {
+				// don't refuse the requests when redis can't verify the budget
+				echolog.GetLogHandler(c, operationID).Warn("tokenBucketScript", logkey.Error, err)
+				return next(c)
+			}
+			if len(result) == 2 && result[0] == 0 {
+				admissionRejects.With(prometheus.Labels{"operation": operationID, "reason": "rate_limit"}).Inc()
+				retryAfter := int(math.Ceil(float64(result[1]) / 1000))
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return JSONProblemf(c, http.StatusTooManyRequests, "rate limit exceeded: %g requests per second, burst %g", bucket.rate, bucket.burst)
+			}
+			return next(c)
+		}
+	}
+}
+
+// newQueueLengthWatcher returns a watcher of the feed queues length,
+// updated every interval, 5s if not positive.
+func newQueueLengthWatcher(r *redis.Client, interval time.Duration) *queueLengthWatcher {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &queueLengthWatcher{redis: r, interval: interval}
+}
+
+// run updates the queue length every interval until ctx is done
+func (w *queueLengthWatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if length, err := w.queueLength(ctx); err != nil {
+			slog.Warn("admission queue length", logkey.Error, err)
+		} else {
+			w.length.Store(length)
+			admissionQueueLength.Set(float64(length))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// queueLength returns the sum of the feed queues length
+func (w *queueLengthWatcher) queueLength(ctx context.Context) (int64, error) {
+	var keys []string
+	iter := w.redis.Scan(ctx, 0, cachekeys.QueuePrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return 0, fmt.Errorf("SCAN %s*: %w", cachekeys.QueuePrefix, err)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	cmds, err := w.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.LLen(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("LLEN: %w", err)
+	}
+	var length int64
+	for _, cmd := range cmds {
+		length += cmd.(*redis.IntCmd).Val()
+	}
+	return length, nil
+}
@@ -0,0 +1,190 @@
+package feederhandlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+type (
+	// countReader counts the bytes read from r
+	countReader struct {
+		r io.Reader
+		n int64
+	}
+
+	// limitReader returns a *http.MaxBytesError when more than max bytes are
+	// read from r.
+	limitReader struct {
+		r   io.Reader
+		n   int64
+		max int64
+	}
+
+	// zstdLimitReader returns a *http.MaxBytesError when the zstd decoder
+	// r refuses a frame window or a decoded size exceeding max bytes.
+	zstdLimitReader struct {
+		r   io.Reader
+		max int64
+	}
+
+	readCloser struct {
+		io.Reader
+		io.Closer
+	}
+)
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *limitReader) Read(p []byte) (int, error) {
+	if r.n > r.max {
+		return 0, &http.MaxBytesError{Limit: r.max}
+	}
+	if remain := r.max - r.n + 1; int64(len(p)) > remain {
+		p = p[:remain]
+	}
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.n > r.max {
+		return n, &http.MaxBytesError{Limit: r.max}
+	}
+	return n, err
+}
+
+func (r *zstdLimitReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	switch {
+	case errors.Is(err, zstd.ErrWindowSizeExceeded),
+		errors.Is(err, zstd.ErrDecoderSizeExceeded),
+		errors.Is(err, zstd.ErrFrameSizeExceeded):
+		return n, &http.MaxBytesError{Limit: r.max}
+	}
+	return n, err
+}
+
+// zstdDecoderOptions returns the zstd decoder options limiting the frame
+// window and the decoded size to maxBytes, so a small posted frame header
+// can't make the decoder allocate more than the maximum body size.
+func zstdDecoderOptions(maxBytes int64) []zstd.DOption {
+	options := []zstd.DOption{zstd.WithDecoderConcurrency(1)}
+	if maxBytes <= 0 {
+		return options
+	}
+	window := uint64(max(maxBytes, zstd.MinWindowSize))
+	return append(options, zstd.WithDecoderMaxWindow(min(window, zstd.MaxWindowSize)), zstd.WithDecoderMaxMemory(window))
+}
+
+// BodyMiddleware returns a middleware that decodes the gzip or zstd
+// Content-Encoding of the feed requests body, and enforces the operation
+// maximum body size.
+//
+// The maximum size applies to both the posted and the decoded body. It is
+// read from the feeder.body.max_bytes config, or from
+// feeder.body.max_bytes_by_operation.<operationId> when defined.
+//
+// The zstd decoder window and memory are also limited to the maximum size.
+//
+// The non-multipart bodies are fully read by the middleware, so the 413
+// status is returned before the handler is called. The multipart bodies are
+// streamed to the handler, which is responsible for the 413 status on
+// *http.MaxBytesError.
+func BodyMiddleware(prefix string) echo.MiddlewareFunc {
+	operationIDs := make(map[string]string)
+	for k, route := range specRoutes(prefix) {
+		if route.Operation.RequestBody != nil {
+			operationIDs[k] = route.Operation.OperationID
+		}
+	}
+	defaultMaxBytes := int64(viper.GetSizeInBytes("feeder.body.max_bytes"))
+	maxBytesByOperation := make(map[string]int64)
+	for k := range viper.GetStringMap("feeder.body.max_bytes_by_operation") {
+		maxBytesByOperation[k] = int64(viper.GetSizeInBytes("feeder.body.max_bytes_by_operation." + k))
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			operationID, ok := operationIDs[req.Method+" "+c.Path()]
+			if !ok || req.Body == nil {
+				return next(c)
+			}
+			maxBytes := defaultMaxBytes
+			if v, ok := maxBytesByOperation[strings.ToLower(operationID)]; ok {
+				maxBytes = v
+			}
+			if maxBytes > 0 && req.ContentLength > maxBytes {
+				return JSONProblemf(c, http.StatusRequestEntityTooLarge, "body exceeds %d bytes", maxBytes)
+			}
+
+			encoding := strings.ToLower(strings.TrimSpace(req.Header.Get(echo.HeaderContentEncoding)))
+			var postedReader io.Reader = req.Body
+			if maxBytes > 0 {
+				postedReader = &limitReader{r: req.Body, max: maxBytes}
+			}
+			posted := &countReader{r: postedReader}
+			var reader io.Reader
+			switch encoding {
+			case "", "identity":
+				reader = posted
+			case "gzip":
+				gr, err := gzip.NewReader(posted)
+				if err != nil {
+					return JSONProblemf(c, http.StatusBadRequest, "gzip: %s", err)
+				}
+				defer func() { _ = gr.Close() }()
+				reader = gr
+			case "zstd":
+				zr, err := zstd.NewReader(posted, zstdDecoderOptions(maxBytes)...)
+				if err != nil {
+					return JSONProblemf(c, http.StatusBadRequest, "zstd: %s", err)
+				}
+				defer zr.Close()
+				reader = zr
+				if maxBytes > 0 {
+					reader = &zstdLimitReader{r: zr, max: maxBytes}
+				}
+			default:
+				return JSONProblemf(c, http.StatusUnsupportedMediaType, "unsupported content encoding: %s", encoding)
+			}
+			if maxBytes > 0 && reader != posted {
+				reader = &limitReader{r: reader, max: maxBytes}
+			}
+			decoded := &countReader{r: reader}
+			defer func() {
+				labels := prometheus.Labels{"operation": operationID, "encoding": encoding}
+				bodyPostedBytes.With(labels).Observe(float64(posted.n))
+				bodyDecodedBytes.With(labels).Observe(float64(decoded.n))
+			}()
+
+			req.Header.Del(echo.HeaderContentEncoding)
+			if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+				req.Body = readCloser{Reader: decoded, Closer: req.Body}
+				req.ContentLength = -1
+				return next(c)
+			}
+
+			b, err := io.ReadAll(decoded)
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return JSONProblemf(c, http.StatusRequestEntityTooLarge, "body exceeds %d bytes", maxBytesErr.Limit)
+			} else if err != nil {
+				return JSONProblemf(c, http.StatusBadRequest, "read body: %s", err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(b))
+			req.ContentLength = int64(len(b))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package feederhandlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestZstdDecoderOptionsLimitWindow(t *testing.T) {
+	var b bytes.Buffer
+	zw, err := zstd.NewWriter(&b, zstd.WithWindowSize(8<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// flush a first block, so the frame header declares the window size
+	// instead of the single segment content size
+	if _, err := zw.Write(bytes.Repeat([]byte("a"), 1000)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	decode := func(maxBytes int64) error {
+		zr, err := zstd.NewReader(bytes.NewReader(b.Bytes()), zstdDecoderOptions(maxBytes)...)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		_, err = io.ReadAll(&zstdLimitReader{r: zr, max: maxBytes})
+		return err
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if err := decode(64 << 10); !errors.As(err, &maxBytesErr) {
+		t.Errorf("decode with a window larger than the maximum body size: got %v, expected a *http.MaxBytesError", err)
+	}
+	if err := decode(16 << 20); err != nil {
+		t.Errorf("decode with a window smaller than the maximum body size: %s", err)
+	}
+}
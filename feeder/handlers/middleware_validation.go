@@ -0,0 +1,208 @@
+package feederhandlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+
+	"github.com/opensvc/oc3/util/echolog"
+)
+
+type (
+	// validationIssue is a request validation error of a request field
+	validationIssue struct {
+		field  string
+		reason string
+	}
+)
+
+const (
+	// validationModeOff disables the request validation
+	validationModeOff = "off"
+
+	// validationModeWarn logs and counts the invalid requests, then calls
+	// the handler
+	validationModeWarn = "warn"
+
+	// validationModeStrict logs and counts the invalid requests, then
+	// answers 400
+	validationModeStrict = "strict"
+
+	// validationMetricFieldDepth is the maximum number of elements of the
+	// validationRejects field label
+	validationMetricFieldDepth = 3
+)
+
+// ValidationMiddleware returns a middleware that validates the requests
+// parameters and body against the SCHEMA operation.
+//
+// The validation mode is read from the feeder.validation.mode config, or from
+// feeder.validation.mode_by_operation.<operationId> when defined:
+//
+//	off:    no validation (default)
+//	warn:   log and count the invalid requests, then call the handler
+//	strict: log and count the invalid requests, then answer 400
+//
+// The multipart bodies are not validated, their parameters are.
+func ValidationMiddleware(prefix string) echo.MiddlewareFunc {
+	routes := specRoutes(prefix)
+	defaultMode := viper.GetString("feeder.validation.mode")
+	modeByOperation := viper.GetStringMapString("feeder.validation.mode_by_operation")
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		SkipSettingDefaults: true,
+		// authentication is verified by the auth middleware
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	multipartOptions := *options
+	multipartOptions.ExcludeRequestBody = true
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route, ok := routes[req.Method+" "+c.Path()]
+			if !ok {
+				return next(c)
+			}
+			operationID := route.Operation.OperationID
+			mode := defaultMode
+			if v, ok := modeByOperation[strings.ToLower(operationID)]; ok {
+				mode = v
+			}
+			switch mode {
+			case validationModeWarn, validationModeStrict:
+			default:
+				return next(c)
+			}
+
+			pathParams := make(map[string]string)
+			for i, name := range c.ParamNames() {
+				pathParams[name] = c.ParamValues()[i]
+			}
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+				input.Options = &multipartOptions
+			}
+			err := openapi3filter.ValidateRequest(req.Context(), input)
+			if err == nil {
+				return next(c)
+			}
+
+			issues := validationIssues(err)
+			body := requestBodySchema(route.Operation)
+			texts := make([]string, len(issues))
+			for i, issue := range issues {
+				validationRejects.With(prometheus.Labels{"operation": operationID, "field": validationMetricField(issue.field, body)}).Inc()
+				texts[i] = fmt.Sprintf("%s: %s", issue.field, issue.reason)
+			}
+			text := strings.Join(texts, "; ")
+			log := echolog.GetLogHandler(c, operationID)
+			if mode == validationModeWarn {
+				log.Warn(fmt.Sprintf("invalid request: %s", text))
+				return next(c)
+			}
+			log.Info(fmt.Sprintf("rejected invalid request: %s", text))
+			return JSONProblemf(c, http.StatusBadRequest, "invalid request: %s", text)
+		}
+	}
+}
+
+// validationIssues flattens the openapi3filter.ValidateRequest error to the
+// list of invalid fields. The field of a body schema error is "body" followed
+// by the JSON pointer of the invalid value, dot separated. The field of a
+// parameter error is "<parameter location>.<parameter name>".
+func validationIssues(err error) []validationIssue {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var l []validationIssue
+		for _, err := range e {
+			l = append(l, validationIssues(err)...)
+		}
+		return l
+	case *openapi3filter.RequestError:
+		field := "request"
+		if e.Parameter != nil {
+			field = e.Parameter.In + "." + e.Parameter.Name
+		} else if e.RequestBody != nil {
+			field = "body"
+		}
+		if e.Err == nil {
+			return []validationIssue{{field: field, reason: e.Reason}}
+		}
+		l := validationIssues(e.Err)
+		for i := range l {
+			if l[i].field == "" {
+				l[i].field = field
+			} else {
+				l[i].field = field + "." + l[i].field
+			}
+		}
+		return l
+	case *openapi3.SchemaError:
+		return []validationIssue{{field: strings.Join(e.JSONPointer(), "."), reason: e.Reason}}
+	default:
+		return []validationIssue{{reason: err.Error()}}
+	}
+}
+
+// validationMetricField returns the field label value of the
+// validationRejects counter: the schema path of the invalid field, with the
+// array indexes and the map keys templated as "*", truncated to
+// validationMetricFieldDepth elements. The node names, paths and other values
+// used as keys never reach the label, so its cardinality stays bounded by the
+// SCHEMA.
+func validationMetricField(field string, body *openapi3.Schema) string {
+	l := strings.Split(field, ".")
+	if len(l) > validationMetricFieldDepth {
+		l = l[:validationMetricFieldDepth]
+	}
+	if l[0] != "body" {
+		// "request" or "<parameter location>.<parameter name>"
+		return strings.Join(l, ".")
+	}
+	schema := body
+	for i := 1; i < len(l); i++ {
+		if schema == nil {
+			l[i] = "*"
+			continue
+		}
+		if v, ok := schema.Properties[l[i]]; ok && v != nil {
+			schema = v.Value
+			continue
+		}
+		l[i] = "*"
+		switch {
+		case schema.Items != nil:
+			schema = schema.Items.Value
+		case schema.AdditionalProperties.Schema != nil:
+			schema = schema.AdditionalProperties.Schema.Value
+		default:
+			schema = nil
+		}
+	}
+	return strings.Join(l, ".")
+}
+
+// requestBodySchema returns the json request body schema of the operation,
+// or nil if the operation has none.
+func requestBodySchema(op *openapi3.Operation) *openapi3.Schema {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil
+	}
+	mt := op.RequestBody.Value.Content.Get(echo.MIMEApplicationJSON)
+	if mt == nil || mt.Schema == nil {
+		return nil
+	}
+	return mt.Schema.Value
+}
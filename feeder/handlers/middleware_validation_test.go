@@ -0,0 +1,32 @@
+package feederhandlers
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestValidationMetricField(t *testing.T) {
+	node := openapi3.NewObjectSchema().WithProperty("frozen", openapi3.NewBoolSchema())
+	body := openapi3.NewObjectSchema().
+		WithProperty("nodes", openapi3.NewObjectSchema().WithAdditionalProperties(node)).
+		WithProperty("tags", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()))
+	cases := map[string]string{
+		"request":                   "request",
+		"query.limit":               "query.limit",
+		"body":                      "body",
+		"body.tags.12":              "body.tags.*",
+		"body.nodes.node1.frozen":   "body.nodes.*",
+		"body.nodes.node2":          "body.nodes.*",
+		"body.unknown.key":          "body.*.*",
+		"body.nodes.node1.frozen.x": "body.nodes.*",
+	}
+	for field, expected := range cases {
+		if got := validationMetricField(field, body); got != expected {
+			t.Errorf("validationMetricField(%q) = %q, expected %q", field, got, expected)
+		}
+	}
+	if got := validationMetricField("body.nodes.node1", nil); got != "body.*.*" {
+		t.Errorf("validationMetricField without schema = %q, expected %q", got, "body.*.*")
+	}
+}
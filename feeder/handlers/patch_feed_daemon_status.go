@@ -0,0 +1,150 @@
+package feederhandlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/feeder"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+var (
+	// daemonStatusCASScript stores the daemon status ARGV[3] of the node ARGV[1]
+	// and increments its generation, only if the current generation is ARGV[2].
+	// It returns the new generation, or -1 if the current generation differs.
+	daemonStatusCASScript = redis.NewScript(`
+if redis.call('HGET', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return -1
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+return redis.call('HINCRBY', KEYS[2], ARGV[1], 1)
+`)
+)
+
+// PatchDaemonStatus applies the posted JSON merge patch to the data of the
+// node last accepted daemon status, and stores the result as the new daemon
+// status. It answers 409 when the last accepted daemon status is missing or
+// when its generation is not the one the patch applies to.
+func (a *Api) PatchDaemonStatus(c echo.Context, params feeder.PatchDaemonStatusParams) error {
+	nodeID, log := getNodeIDAndLogger(c, "PatchDaemonStatus")
+	if nodeID == "" {
+		return JSONNodeAuthProblem(c)
+	}
+
+	body := c.Request().Body
+	b, err := io.ReadAll(body)
+	defer func() {
+		if err := body.Close(); err != nil {
+			log.Warn("request body Close", logkey.Error, err)
+		}
+	}()
+	if err != nil {
+		log.Warn("request ReadAll", logkey.Error, err)
+		return JSONProblemf(c, http.StatusBadRequest, "ReadAll: %s", err)
+	}
+	patchData := &feeder.PatchDaemonStatus{}
+	if err := unmarshalUseNumber(b, patchData); err != nil {
+		log.Debug("request Unmarshal", logkey.Error, err)
+		return JSONProblem(c, http.StatusBadRequest, err.Error())
+	}
+	if !validDaemonStatusVersion(patchData.Version) {
+		msg := fmt.Sprintf("unexpected version %s", patchData.Version)
+		log.Debug(msg)
+		return JSONProblem(c, http.StatusBadRequest, msg)
+	}
+
+	ctx := c.Request().Context()
+
+	// The base is read before its generation: the base and its generation
+	// are updated atomically and the generation only increases, so a
+	// matching generation guarantees the base is the expected one.
+	log.Debug("HGet FeedDaemonStatusH")
+	base, err := a.Redis.HGet(ctx, cachekeys.FeedDaemonStatusH, nodeID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return JSONProblem(c, http.StatusConflict, "missing daemon status, POST /daemon/status is required")
+	} else if err != nil {
+		log.Error("HGet FeedDaemonStatusH", logkey.Error, err)
+		return JSONError(c)
+	}
+	log.Debug("HGet FeedDaemonStatusGenerationH")
+	generation, err := a.Redis.HGet(ctx, cachekeys.FeedDaemonStatusGenerationH, nodeID).Int64()
+	if errors.Is(err, redis.Nil) {
+		return JSONProblem(c, http.StatusConflict, "missing daemon status generation, POST /daemon/status is required")
+	} else if err != nil {
+		log.Error("HGet FeedDaemonStatusGenerationH", logkey.Error, err)
+		return JSONError(c)
+	} else if generation != patchData.Generation {
+		return JSONProblemf(c, http.StatusConflict, "daemon status generation is %d, POST /daemon/status is required", generation)
+	}
+
+	baseData := &feeder.PostDaemonStatus{}
+	if err := unmarshalUseNumber(base, baseData); err != nil {
+		log.Warn("base Unmarshal", logkey.Error, err)
+		return JSONProblem(c, http.StatusConflict, "invalid daemon status, POST /daemon/status is required")
+	}
+	data, _ := jsonMergePatch(baseData.Data, patchData.Patch).(map[string]any)
+	postData := feeder.PostDaemonStatus{
+		Changes:           patchData.Changes,
+		Data:              data,
+		PreviousUpdatedAt: patchData.PreviousUpdatedAt,
+		UpdatedAt:         patchData.UpdatedAt,
+		Version:           patchData.Version,
+	}
+	merged, err := json.Marshal(postData)
+	if err != nil {
+		log.Error("Marshal", logkey.Error, err)
+		return JSONProblem(c, http.StatusInternalServerError, "unexpected marshall error")
+	}
+
+	log.Debug("daemonStatusCASScript")
+	keys := []string{cachekeys.FeedDaemonStatusH, cachekeys.FeedDaemonStatusGenerationH}
+	args := []any{nodeID, strconv.FormatInt(patchData.Generation, 10), string(merged)}
+	if newGeneration, err := daemonStatusCASScript.Run(ctx, a.Redis, keys, args...).Int64(); err != nil {
+		log.Error("daemonStatusCASScript", logkey.Error, err)
+		return JSONError(c)
+	} else if newGeneration < 0 {
+		log.Debug("daemon status generation changed")
+		return JSONProblem(c, http.StatusConflict, "daemon status changed, POST /daemon/status is required")
+	} else {
+		generation = newGeneration
+	}
+	return a.acceptDaemonStatus(c, log, nodeID, generation, patchData.Changes, params.Sync)
+}
+
+// jsonMergePatch returns target with patch applied, following the RFC 7386
+// JSON merge patch algorithm. The target maps are modified in place.
+func jsonMergePatch(target, patch any) any {
+	patchMap, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetMap, ok := target.(map[string]any)
+	if !ok || targetMap == nil {
+		targetMap = make(map[string]any)
+	}
+	for k, v := range patchMap {
+		if v == nil {
+			delete(targetMap, k)
+		} else {
+			targetMap[k] = jsonMergePatch(targetMap[k], v)
+		}
+	}
+	return targetMap
+}
+
+// unmarshalUseNumber is json.Unmarshal preserving the numbers as json.Number,
+// so they are marshaled back without float64 precision loss.
+func unmarshalUseNumber(b []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
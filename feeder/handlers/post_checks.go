@@ -36,14 +36,9 @@ func (a *Api) PostChecks(c echo.Context) error {
 		return JSONProblemf(c, http.StatusInternalServerError, "Unable to HSet check: %s", err)
 	}
 
-	if err := a.Redis.LRem(ctx, cachekeys.FeedChecksQ, 0, nodeID).Err(); err != nil {
-		log.Error("LRem FeedChecksQ", logkey.Error, err)
-		return JSONProblemf(c, http.StatusInternalServerError, "Unable to LRem check: %s", err)
-	}
-
-	if err := a.Redis.LPush(ctx, cachekeys.FeedChecksQ, nodeID).Err(); err != nil {
-		log.Error("LPush FeedChecksQ", logkey.Error, err)
-		return JSONProblemf(c, http.StatusInternalServerError, "Unable to LPush check: %s", err)
+	if err := a.pushNotPending(ctx, log, cachekeys.FeedChecksPendingH, cachekeys.FeedChecksQ, nodeID); err != nil {
+		log.Error("pushNotPending", logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "Unable to push check: %s", err)
 	}
 
 	msg := fmt.Sprintf("Checks Vars: %v Vals: %v", payload.Vars, payload.Vals)
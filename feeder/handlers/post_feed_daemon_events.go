@@ -0,0 +1,220 @@
+package feederhandlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/feeder"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+type (
+	// daemonEventOp is the update of the cluster daemon status hash of a
+	// daemon event: set is the json encoded value of the fields, or the
+	// fields are deleted when set is empty. change is the object or
+	// instance change to apply by the worker.
+	daemonEventOp struct {
+		fields []string
+		set    string
+		change string
+	}
+)
+
+// PostDaemonEvents applies the daemon events to the cluster daemon status
+// hash seeded by the worker from the last v3 daemon status, and queues the
+// node daemon events job.
+func (a *Api) PostDaemonEvents(c echo.Context, params feeder.PostDaemonEventsParams) error {
+	nodeID, log := getNodeIDAndLogger(c, "PostDaemonEvents")
+	if nodeID == "" {
+		return JSONNodeAuthProblem(c)
+	}
+	clusterID := clusterIDFromContext(c)
+	if clusterID == "" {
+		log.Debug("need resync: unknown cluster id")
+		return JSONProblem(c, http.StatusConflict, "unknown node cluster, POST /daemon/status first")
+	}
+
+	body := c.Request().Body
+	b, err := io.ReadAll(body)
+	defer func() {
+		if err := body.Close(); err != nil {
+			log.Warn("request body Close", logkey.Error, err)
+		}
+	}()
+	if err != nil {
+		log.Warn("request ReadAll", logkey.Error, err)
+		return JSONProblemf(c, http.StatusBadRequest, "ReadAll: %s", err)
+	}
+	postData := &feeder.PostDaemonEvents{}
+	if err := json.Unmarshal(b, postData); err != nil {
+		log.Debug("request Unmarshal", logkey.Error, err)
+		return JSONProblem(c, http.StatusBadRequest, err.Error())
+	}
+	if !strings.HasPrefix(postData.Version, "3.") {
+		msg := fmt.Sprintf("unexpected version %s", postData.Version)
+		log.Debug(msg)
+		return JSONProblem(c, http.StatusBadRequest, msg)
+	}
+	ops := make([]daemonEventOp, len(postData.Events))
+	for i, e := range postData.Events {
+		op, err := newDaemonEventOp(e)
+		if err != nil {
+			log.Debug("invalid event", logkey.Error, err)
+			return JSONProblemf(c, http.StatusBadRequest, "event %d: %s", i, err)
+		}
+		ops[i] = op
+	}
+
+	ctx := c.Request().Context()
+	key := cachekeys.FeedDaemonEventsH + clusterID
+	if n, err := a.Redis.Exists(ctx, key).Result(); err != nil {
+		log.Error("Exists FeedDaemonEventsH", logkey.Error, err)
+		return JSONError(c)
+	} else if n == 0 {
+		// still waiting for the worker to apply a v3 daemon status.
+		log.Debug("need resync: no cluster daemon status")
+		return JSONProblem(c, http.StatusConflict, "no cluster daemon status, POST /daemon/status first")
+	}
+
+	var changes []string
+	log.Debug("HSet and HDel FeedDaemonEventsH")
+	if _, err := a.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, op := range ops {
+			if op.set == "" {
+				pipe.HDel(ctx, key, op.fields...)
+			} else {
+				for _, field := range op.fields {
+					pipe.HSet(ctx, key, field, op.set)
+				}
+			}
+			// keep the node fields index of the worker up to date
+			for _, field := range op.fields {
+				nodeKey, ok := feeder.DaemonEventsNodeKey(clusterID, field)
+				switch {
+				case !ok:
+				case op.set == "":
+					pipe.SRem(ctx, nodeKey, field)
+				default:
+					pipe.SAdd(ctx, nodeKey, field)
+				}
+			}
+			if op.change != "" {
+				changes = append(changes, op.change)
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Error("HSet and HDel FeedDaemonEventsH", logkey.Error, err)
+		return JSONError(c)
+	}
+
+	if len(changes) > 0 {
+		key := cachekeys.FeedDaemonStatusChangesS + nodeID
+		log.Debug("SAdd FeedDaemonStatusChangesS", logkey.Changes, changes)
+		if err := a.Redis.SAdd(ctx, key, toAnySlice(changes)...).Err(); err != nil {
+			log.Error("SAdd FeedDaemonStatusChangesS", logkey.Changes, changes, logkey.Error, err)
+			return JSONError(c)
+		}
+	}
+
+	entry := nodeID + "@" + clusterID
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedDaemonEventsP, entry)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
+	if err := a.pushNotPending(ctx, log, cachekeys.FeedDaemonEventsPendingH, cachekeys.FeedDaemonEventsQ, entry); err != nil {
+		log.Error("pushNotPending", logkey.Error, err)
+		return JSONError(c)
+	}
+
+	if objects, err := a.getObjectConfigToFeed(ctx, clusterID); err != nil {
+		log.Warn("getObjectConfigToFeed", logkey.Error, err)
+	} else if len(objects) > 0 {
+		if err := a.removeObjectConfigToFeed(ctx, clusterID); err != nil {
+			log.Warn("removeObjectConfigToFeed", logkey.Error, err)
+		}
+		log.Debug("accepted with detected missing object configs", logkey.Objects, objects)
+		return feedJobResponse(c, w, feeder.DaemonEventsAccepted{ObjectWithoutConfig: &objects})
+	}
+	log.Debug("accepted")
+	return feedJobResponse(c, w, feeder.DaemonEventsAccepted{})
+}
+
+// newDaemonEventOp returns the cluster daemon status hash update of the
+// event. The hash fields are the space separated keys of the event value in
+// the v3 daemon status data.cluster.
+func newDaemonEventOp(e feeder.DaemonEvent) (op daemonEventOp, err error) {
+	var node, path string
+	if e.Node != nil {
+		node = *e.Node
+	}
+	if e.Path != nil {
+		path = *e.Path
+	}
+	needs := func(name, value string) {
+		if err == nil && (value == "" || strings.ContainsAny(value, " \t\n")) {
+			err = fmt.Errorf("%s: invalid or missing %s", e.Kind, name)
+		}
+	}
+	switch e.Kind {
+	case feeder.ClusterConfigUpdated:
+		op.fields = []string{"config"}
+	case feeder.NodeStatusUpdated:
+		needs("node", node)
+		op.fields = []string{"node " + node + " status"}
+	case feeder.NodeMonitorUpdated:
+		needs("node", node)
+		op.fields = []string{"node " + node + " monitor"}
+	case feeder.HeartbeatUpdated:
+		needs("node", node)
+		op.fields = []string{"node " + node + " daemon heartbeat"}
+	case feeder.InstanceStatusUpdated, feeder.InstanceMonitorUpdated, feeder.InstanceConfigUpdated, feeder.InstanceDeleted:
+		needs("node", node)
+		needs("path", path)
+		prefix := "node " + node + " instance " + path + " "
+		switch e.Kind {
+		case feeder.InstanceStatusUpdated:
+			op.fields = []string{prefix + "status"}
+		case feeder.InstanceMonitorUpdated:
+			op.fields = []string{prefix + "monitor"}
+		case feeder.InstanceConfigUpdated:
+			op.fields = []string{prefix + "config"}
+		default:
+			op.fields = []string{prefix + "status", prefix + "monitor", prefix + "config"}
+		}
+		op.change = path + "@" + node
+	case feeder.ObjectStatusUpdated, feeder.ObjectDeleted:
+		needs("path", path)
+		op.fields = []string{"object " + path}
+		op.change = path
+	default:
+		err = fmt.Errorf("unexpected kind %s", e.Kind)
+	}
+	if err != nil {
+		return
+	}
+	if e.Kind == feeder.InstanceDeleted || e.Kind == feeder.ObjectDeleted {
+		return
+	}
+	if e.Data == nil {
+		err = fmt.Errorf("%s: missing data", e.Kind)
+		return
+	}
+	b, err := json.Marshal(*e.Data)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", e.Kind, err)
+		return
+	}
+	op.set = string(b)
+	return
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -15,20 +16,12 @@ import (
 	"github.com/opensvc/oc3/util/logkey"
 )
 
-func (a *Api) PostDaemonStatus(c echo.Context) error {
+func (a *Api) PostDaemonStatus(c echo.Context, params feeder.PostDaemonStatusParams) error {
 	nodeID, log := getNodeIDAndLogger(c, "PostDaemonStatus")
 	if nodeID == "" {
 		return JSONNodeAuthProblem(c)
 	}
 
-	mChange := make(map[string]struct{})
-
-	mergeChanges := func(s string) {
-		for _, v := range strings.Fields(s) {
-			mChange[v] = struct{}{}
-		}
-	}
-
 	body := c.Request().Body
 	b, err := io.ReadAll(body)
 	defer func() {
@@ -44,48 +37,49 @@ func (a *Api) PostDaemonStatus(c echo.Context) error {
 	if err := json.Unmarshal(b, postData); err != nil {
 		log.Debug("request Unmarshal", logkey.Error, err)
 		return JSONProblem(c, http.StatusBadRequest, err.Error())
-	} else {
-		mergeChanges(strings.Join(postData.Changes, " "))
 	}
-	if !strings.HasPrefix(postData.Version, "2.") && !strings.HasPrefix(postData.Version, "3.") {
+	if !validDaemonStatusVersion(postData.Version) {
 		msg := fmt.Sprintf("unexpected version %s", postData.Version)
 		log.Debug(msg)
 		return JSONProblem(c, http.StatusBadRequest, msg)
 	}
 	ctx := c.Request().Context()
-	log.Debug("HSet FeedDaemonStatusH")
-	if err := a.Redis.HSet(ctx, cachekeys.FeedDaemonStatusH, nodeID, string(b)).Err(); err != nil {
-		log.Error("HSet FeedDaemonStatusH", logkey.Error, err)
+	log.Debug("HSet FeedDaemonStatusH and HIncrBy FeedDaemonStatusGenerationH")
+	var generation *redis.IntCmd
+	if _, err := a.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, cachekeys.FeedDaemonStatusH, nodeID, string(b))
+		generation = pipe.HIncrBy(ctx, cachekeys.FeedDaemonStatusGenerationH, nodeID, 1)
+		return nil
+	}); err != nil {
+		log.Error("HSet FeedDaemonStatusH and HIncrBy FeedDaemonStatusGenerationH", logkey.Error, err)
 		return JSONError(c)
 	}
-	if len(mChange) > 0 {
-		// request contains changes, merge them to not yet applied changes
-		log.Debug("HGet FeedDaemonStatusChangesH")
-		redisChanges, err := a.Redis.HGet(ctx, cachekeys.FeedDaemonStatusChangesH, nodeID).Result()
-		switch err {
-		case nil:
-			// merge existing changes
-			mergeChanges(redisChanges)
-		case redis.Nil:
-			// no existing changes to merge
-		default:
-			log.Error("HGet FeedDaemonStatusChangesH", logkey.Error, err)
-			return JSONError(c)
-		}
-		l := make([]string, len(mChange))
-		i := 0
-		for k := range mChange {
-			l[i] = k
-			i++
-		}
-		mergedChanges := strings.Join(l, " ")
-		// push changes
-		log.Debug("HSet FeedDaemonStatusChangesH", logkey.Changes, mergedChanges)
-		if err := a.Redis.HSet(ctx, cachekeys.FeedDaemonStatusChangesH, nodeID, mergedChanges).Err(); err != nil {
-			log.Error("HSet FeedDaemonStatusChangesH", logkey.Changes, mergedChanges, logkey.Error, err)
+	return a.acceptDaemonStatus(c, log, nodeID, generation.Val(), postData.Changes, params.Sync)
+}
+
+// acceptDaemonStatus adds the changes to the not yet applied changes, queues
+// the node daemon status job and returns the DaemonStatusAccepted response
+// with the generation of the stored daemon status.
+func (a *Api) acceptDaemonStatus(c echo.Context, log *slog.Logger, nodeID string, generation int64, changes []string, sync *bool) error {
+	ctx := c.Request().Context()
+	if members := strings.Fields(strings.Join(changes, " ")); len(members) > 0 {
+		// SADD atomically merges the changes to the not yet applied changes,
+		// the worker atomically takes them all with SMEMBERS and DEL in a
+		// transaction.
+		key := cachekeys.FeedDaemonStatusChangesS + nodeID
+		log.Debug("SAdd FeedDaemonStatusChangesS", logkey.Changes, members)
+		if err := a.Redis.SAdd(ctx, key, toAnySlice(members)...).Err(); err != nil {
+			log.Error("SAdd FeedDaemonStatusChangesS", logkey.Changes, members, logkey.Error, err)
 			return JSONError(c)
 		}
 	}
+	w, err := a.newFeedJobWaiter(ctx, log, sync, cachekeys.FeedDaemonStatusP, nodeID)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
 	if err := a.pushNotPending(ctx, log, cachekeys.FeedDaemonStatusPendingH, cachekeys.FeedDaemonStatusQ, nodeID); err != nil {
 		log.Error("pushNotPending", logkey.Error, err)
 		return JSONError(c)
@@ -101,9 +95,21 @@ func (a *Api) PostDaemonStatus(c echo.Context) error {
 			}
 			// TODO: add metric about PostDaemonStatus accepted with detected missing object configs
 			log.Debug("accepted with detected missing object configs", logkey.Objects, objects)
-			return c.JSON(http.StatusAccepted, feeder.DaemonStatusAccepted{ObjectWithoutConfig: &objects})
+			return feedJobResponse(c, w, feeder.DaemonStatusAccepted{Generation: generation, ObjectWithoutConfig: &objects})
 		}
 	}
 	log.Debug("accepted")
-	return c.JSON(http.StatusAccepted, feeder.DaemonStatusAccepted{})
+	return feedJobResponse(c, w, feeder.DaemonStatusAccepted{Generation: generation})
+}
+
+func validDaemonStatusVersion(version string) bool {
+	return strings.HasPrefix(version, "2.") || strings.HasPrefix(version, "3.")
+}
+
+func toAnySlice(l []string) []any {
+	r := make([]any, len(l))
+	for i, v := range l {
+		r[i] = v
+	}
+	return r
 }
@@ -31,7 +31,7 @@ import (
 */
 
 // PostInstanceAction handles POST /action/begin
-func (a *Api) PostInstanceAction(c echo.Context) error {
+func (a *Api) PostInstanceAction(c echo.Context, params feeder.PostInstanceActionParams) error {
 	nodeID, log := getNodeIDAndLogger(c, "PostInstanceAction")
 	if nodeID == "" {
 		return JSONNodeAuthProblem(c)
@@ -67,6 +67,13 @@ func (a *Api) PostInstanceAction(c echo.Context) error {
 	uuid := uuid.New().String()
 	idx := fmt.Sprintf("%s@%s@%s:%s", payload.Path, nodeID, ClusterID, uuid)
 
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedInstanceActionP, idx)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
 	log.Debug("Hset FeedInstanceActionH")
 	if _, err := a.Redis.HSet(ctx, keyH, idx, b).Result(); err != nil {
 		log.Error("Hset FeedInstanceActionH", logkey.Error, err)
@@ -79,5 +86,5 @@ func (a *Api) PostInstanceAction(c echo.Context) error {
 	}
 
 	log.Debug("accepted")
-	return c.JSON(http.StatusAccepted, feeder.ActionRequestAccepted{Uuid: uuid})
+	return feedJobResponse(c, w, feeder.ActionRequestAccepted{Uuid: uuid})
 }
@@ -13,7 +13,7 @@ import (
 
 // PostInstanceResourceInfo will populate FeedInstanceResourceInfo <path>@<nodeID>@<clusterID>
 // with posted instance resource information. The auth middleware has prepared nodeID and clusterID.
-func (a *Api) PostInstanceResourceInfo(c echo.Context) error {
+func (a *Api) PostInstanceResourceInfo(c echo.Context, params feeder.PostInstanceResourceInfoParams) error {
 	nodeID, log := getNodeIDAndLogger(c, "PostInstanceResourceInfo")
 	if nodeID == "" {
 		return JSONNodeAuthProblem(c)
@@ -45,6 +45,13 @@ func (a *Api) PostInstanceResourceInfo(c echo.Context) error {
 	}
 	ctx := c.Request().Context()
 	idx := data.Path + "@" + nodeID + "@" + clusterID
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedInstanceResourceInfoP, idx)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
 	log.Debug("HSet keyH")
 	if err := a.Redis.HSet(ctx, keyH, idx, b).Err(); err != nil {
 		log.Error("HSet keyH", logkey.Error, err)
@@ -55,5 +62,5 @@ func (a *Api) PostInstanceResourceInfo(c echo.Context) error {
 		log.Error("pushNotPending", logkey.Error, err)
 		return JSONError(c)
 	}
-	return c.JSON(http.StatusAccepted, nil)
+	return feedJobResponse(c, w, nil)
 }
@@ -1,14 +1,11 @@
 package feederhandlers
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/labstack/echo/v4"
 
 	"github.com/opensvc/oc3/cachekeys"
@@ -26,13 +23,6 @@ func (a *Api) PostInstanceStatus(c echo.Context, params feeder.PostInstanceStatu
 		keyH        = cachekeys.FeedInstanceStatusH
 		keyQ        = cachekeys.FeedInstanceStatusQ
 		keyPendingH = cachekeys.FeedInstanceStatusPendingH
-
-		syncMode bool
-		pubsub   *redis.PubSub
-		doneC    chan any
-
-		// timeout is the default timeout for api calls
-		timeout = 2 * time.Second
 	)
 
 	clusterID := clusterIDFromContext(c)
@@ -45,13 +35,6 @@ func (a *Api) PostInstanceStatus(c echo.Context, params feeder.PostInstanceStatu
 		return JSONProblem(c, http.StatusBadRequest, err.Error())
 	}
 
-	if params.Sync != nil && *params.Sync {
-		syncMode = true
-		if a.SyncTimeout > 0 {
-			timeout = a.SyncTimeout
-		}
-	}
-
 	if !strings.HasPrefix(payload.Version, "2.") {
 		log.Error(fmt.Sprintf("unexpected version %s", payload.Version))
 		return JSONProblemf(c, http.StatusBadRequest, "unsupported data client version: %s", payload.Version)
@@ -69,56 +52,14 @@ func (a *Api) PostInstanceStatus(c echo.Context, params feeder.PostInstanceStatu
 
 	id := fmt.Sprintf("%s@%s@%s", payload.Path, nodeID, clusterID)
 
-	ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
-	defer cancel()
-
-	if syncMode {
-		pubsub = a.Redis.Subscribe(ctx, cachekeys.FeedInstanceStatusP)
-		defer func() {
-			if err := pubsub.Close(); err != nil {
-				log.Error("redis pubsub Close", logkey.Error, err)
-			}
-		}()
-		if i, err := pubsub.Receive(ctx); err != nil {
-			log.Error("redis pubsub Receive", logkey.Error, err)
-			return JSONError(c)
-		} else {
-			switch i.(type) {
-			case *redis.Subscription:
-			default:
-				log.Error(fmt.Sprintf("redis pubsub Receive unexpected message type %T", i))
-				return JSONError(c)
-			}
-		}
-		doneC = make(chan any)
-		go func() {
-			// Wait for confirmation that the data has been stored
-			for {
-				// Ensure return on ctx done
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
-
-				if i, err := pubsub.Receive(ctx); err != nil {
-					log.Debug("redis pubsub Receive", logkey.Error, err)
-					return
-				} else {
-					switch m := i.(type) {
-					case *redis.Message:
-						if m == nil {
-							continue
-						} else if m.Payload == id {
-							log.Debug("redis pubsub Received matching id")
-							doneC <- nil
-							return
-						}
-					}
-				}
-			}
-		}()
+	ctx := c.Request().Context()
+
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedInstanceStatusP, id)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
 	}
+	defer w.Close()
 
 	// Store data in Redis hash with generated ID as key
 	log.Debug("Hset keyH")
@@ -132,14 +73,5 @@ func (a *Api) PostInstanceStatus(c echo.Context, params feeder.PostInstanceStatu
 		return JSONError(c)
 	}
 
-	if syncMode {
-		select {
-		case <-doneC:
-			return c.JSON(http.StatusOK, nil)
-		case <-ctx.Done():
-			return c.JSON(http.StatusAccepted, nil)
-		}
-	} else {
-		return c.JSON(http.StatusAccepted, nil)
-	}
+	return feedJobResponse(c, w, nil)
 }
@@ -13,7 +13,7 @@ import (
 
 // PostNodeDisk will populate FeedNodeDiskH <nodename>@<nodeID>@<clusterID>
 // with posted disk, auth middleware has prepared nodeID, clusterID, and nodename.
-func (a *Api) PostNodeDisk(c echo.Context) error {
+func (a *Api) PostNodeDisk(c echo.Context, params feeder.PostNodeDiskParams) error {
 	nodeID, log := getNodeIDAndLogger(c, "PostNodeDisk")
 	if nodeID == "" {
 		return JSONNodeAuthProblem(c)
@@ -42,6 +42,13 @@ func (a *Api) PostNodeDisk(c echo.Context) error {
 	}
 	ctx := c.Request().Context()
 	idx := nodename + "@" + nodeID + "@" + clusterID
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedNodeDiskP, idx)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
 	log.Debug("HSet keyH")
 	if err := a.Redis.HSet(ctx, keyH, idx, b).Err(); err != nil {
 		log.Error("HSet keyH", logkey.Error, err)
@@ -52,5 +59,5 @@ func (a *Api) PostNodeDisk(c echo.Context) error {
 		log.Error("pushNotPending", logkey.Error, err)
 		return JSONError(c)
 	}
-	return c.JSON(http.StatusAccepted, nil)
+	return feedJobResponse(c, w, nil)
 }
@@ -13,7 +13,7 @@ import (
 
 // PostObjectConfig will populate FeedObjectConfigH <path>@<nodeID>@<clusterID>
 // with posted object config. The auth middleware has prepared nodeID and clusterID.
-func (a *Api) PostObjectConfig(c echo.Context) error {
+func (a *Api) PostObjectConfig(c echo.Context, params feeder.PostObjectConfigParams) error {
 	nodeID, log := getNodeIDAndLogger(c, "PostObjectConfig")
 	if nodeID == "" {
 		return JSONNodeAuthProblem(c)
@@ -42,6 +42,13 @@ func (a *Api) PostObjectConfig(c echo.Context) error {
 	}
 	ctx := c.Request().Context()
 	idx := payload.Path + "@" + nodeID + "@" + clusterID
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedObjectConfigP, idx)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
 	log.Debug("Hset keyH")
 	if err := a.Redis.HSet(ctx, keyH, idx, b).Err(); err != nil {
 		log.Error("Hset keyH", logkey.Error, err)
@@ -52,5 +59,5 @@ func (a *Api) PostObjectConfig(c echo.Context) error {
 		log.Error("pushNotPending", logkey.Error, err)
 		return JSONError(c)
 	}
-	return c.JSON(http.StatusAccepted, nil)
+	return feedJobResponse(c, w, nil)
 }
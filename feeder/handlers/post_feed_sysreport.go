@@ -1,69 +1,145 @@
 package feederhandlers
 
 import (
-	"archive/tar"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
-	"io/fs"
-	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
 
 	"github.com/opensvc/oc3/cachekeys"
 	"github.com/opensvc/oc3/feeder"
 	"github.com/opensvc/oc3/util/echolog"
 	"github.com/opensvc/oc3/util/logkey"
+)
 
-	"github.com/spf13/viper"
+type (
+	// errWriter is a writer that records the first error of the underlying writer
+	errWriter struct {
+		w   io.Writer
+		err error
+	}
 )
 
-type sysreportData struct {
-	NeedCommit bool     `json:"need_commit"`
-	Deleted    []string `json:"deleted"`
-	NodeID     string   `json:"node_id"`
+var (
+	// errSysreportSpool wraps the spool file system errors
+	errSysreportSpool = errors.New("spool")
+)
+
+const (
+	// maxSysreportDeletedValueSize is the maximum size of a "deleted" form value
+	maxSysreportDeletedValueSize = 64 * 1024
+)
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+	return n, err
 }
 
+// PostNodeSysReport verifies and spools the posted sysreport archive, then
+// queues it to FeedSysreportQ. The multipart body is streamed: the archive is
+// never fully buffered in memory. The archive extraction and the commit of the
+// node sysreport repository are done by the worker.
 func (a *Api) PostNodeSysReport(ctx echo.Context) error {
 	log := echolog.GetLogHandler(ctx, "PostNodeSysReport")
-	file, err := ctx.FormFile("file")
+
+	nodeID := ctx.Get(XNodeID).(string)
+
+	mr, err := ctx.Request().MultipartReader()
 	if err != nil {
-		return JSONProblemf(ctx, http.StatusBadRequest, "FormFile: %s", err)
+		return JSONProblemf(ctx, http.StatusBadRequest, "MultipartReader: %s", err)
 	}
 
-	var payload feeder.SysReport
-	if values, ok := ctx.Request().MultipartForm.Value["deleted"]; ok {
-		payload.Deleted = values
+	spoolDir := filepath.Join(viper.GetString("scheduler.directories.uploads"), "sysreport_spool")
+	checker := &sysreportArchiveChecker{
+		maxBytes:   int64(viper.GetSizeInBytes("feeder.sysreport.max_bytes")),
+		maxEntries: viper.GetInt("feeder.sysreport.max_entries"),
+	}
+	v := feeder.SysreportData{
+		NodeID: nodeID,
+	}
+	removeSpool := func() {
+		if v.Archive != "" {
+			_ = os.Remove(filepath.Join(spoolDir, v.Archive))
+		}
 	}
-	payload.File.InitFromMultipart(file)
 
-	uploadDir := viper.GetString("scheduler.directories.uploads")
-	sysreportDir := filepath.Join(uploadDir, "sysreport")
-	if err := os.MkdirAll(sysreportDir, 0755); err != nil {
-		log.Error("can't create sysreport dir", logkey.Error, err)
-		return JSONProblem(ctx, http.StatusInternalServerError, "can't create sysreport dir")
+	var maxBytesErr *http.MaxBytesError
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		} else if errors.As(err, &maxBytesErr) {
+			removeSpool()
+			return JSONProblemf(ctx, http.StatusRequestEntityTooLarge, "body exceeds %d bytes", maxBytesErr.Limit)
+		} else if err != nil {
+			removeSpool()
+			return JSONProblemf(ctx, http.StatusBadRequest, "NextPart: %s", err)
+		}
+		switch part.FormName() {
+		case "deleted":
+			b, err := io.ReadAll(io.LimitReader(part, maxSysreportDeletedValueSize+1))
+			if errors.As(err, &maxBytesErr) {
+				removeSpool()
+				return JSONProblemf(ctx, http.StatusRequestEntityTooLarge, "body exceeds %d bytes", maxBytesErr.Limit)
+			} else if err != nil {
+				removeSpool()
+				return JSONProblemf(ctx, http.StatusBadRequest, "read deleted: %s", err)
+			} else if len(b) > maxSysreportDeletedValueSize {
+				removeSpool()
+				return JSONProblemf(ctx, http.StatusRequestEntityTooLarge, "deleted value exceeds %d bytes", maxSysreportDeletedValueSize)
+			}
+			v.Deleted = append(v.Deleted, string(b))
+		case "file":
+			if v.Archive != "" || !strings.HasSuffix(part.FileName(), ".tar") {
+				continue
+			}
+			name, err := spoolSysreportArchive(part, spoolDir, nodeID, checker)
+			switch {
+			case err == nil:
+				v.Archive = name
+			case errors.As(err, &maxBytesErr):
+				return JSONProblemf(ctx, http.StatusRequestEntityTooLarge, "body exceeds %d bytes", maxBytesErr.Limit)
+			case errors.Is(err, errSysreportQuota):
+				return ctx.JSON(http.StatusRequestEntityTooLarge, feeder.SysReportProblem{Text: err.Error(), Rejected: checker.rejected})
+			case errors.Is(err, errSysreportSpool):
+				log.Error("spool sysreport archive", logkey.Error, err)
+				return JSONProblem(ctx, http.StatusInternalServerError, "can't spool sysreport archive")
+			default:
+				return JSONProblemf(ctx, http.StatusBadRequest, "invalid sysreport archive: %s", err)
+			}
+		}
 	}
 
-	nodeID := ctx.Get(XNodeID).(string)
+	checker.CheckDeleted(v.Deleted)
+	if len(checker.rejected) > 0 {
+		removeSpool()
+		log.Warn(fmt.Sprintf("sysreport rejected: %d unsafe entries", len(checker.rejected)))
+		return ctx.JSON(http.StatusBadRequest, feeder.SysReportProblem{Text: "sysreport has unsafe entries", Rejected: checker.rejected})
+	}
 
-	needCommit := false
-	needCommit = sendSysreportDelete(log, payload.Deleted, sysreportDir, nodeID) || needCommit
-	needCommit = sendSysreportArchive(log, payload, file.Filename, sysreportDir, nodeID) || needCommit
+	if v.Archive == "" && len(v.Deleted) == 0 {
+		return ctx.JSON(http.StatusAccepted, "sysreport accepted")
+	}
 
 	// TODO: Add metric PostNodeSysReport File size
 
-	v := sysreportData{
-		NeedCommit: needCommit,
-		Deleted:    payload.Deleted,
-		NodeID:     nodeID,
-	}
 	if b, err := json.Marshal(v); err != nil {
+		removeSpool()
 		log.Error("Marshal", logkey.Error, err)
 		return JSONProblem(ctx, http.StatusInternalServerError, "unexpected marshall error")
 	} else if err := a.Redis.RPush(ctx.Request().Context(), cachekeys.FeedSysreportQ, string(b)).Err(); err != nil {
+		removeSpool()
 		log.Error("RPush FeedSysreportQ", logkey.Error, err)
 		return JSONProblem(ctx, http.StatusInternalServerError, "unexpected internal feed queue error")
 	}
@@ -71,91 +147,31 @@ func (a *Api) PostNodeSysReport(ctx echo.Context) error {
 	return ctx.JSON(http.StatusAccepted, "sysreport accepted")
 }
 
-func sendSysreportDelete(l *slog.Logger, deleted []string, sysreportDir string, nodeID string) bool {
-	if len(deleted) == 0 {
-		return false
+// spoolSysreportArchive copies the archive from r to a new file of spoolDir,
+// verified by checker, and returns the spooled file name. The spooled file is
+// removed on error.
+func spoolSysreportArchive(r io.Reader, spoolDir, nodeID string, checker *sysreportArchiveChecker) (string, error) {
+	if err := os.MkdirAll(spoolDir, 0755); err != nil {
+		return "", fmt.Errorf("%w: %w", errSysreportSpool, err)
 	}
-	nodeDir := filepath.Join(sysreportDir, nodeID)
-	for _, fpath := range deleted {
-		fpath = strings.TrimSpace(fpath)
-		var relpath string
-		if filepath.IsAbs(fpath) {
-			relpath = "file" + fpath
-		} else {
-			relpath = filepath.Join("file", fpath)
-		}
-		pathToDelete := filepath.Join(nodeDir, relpath)
-		if err := os.Remove(pathToDelete); err != nil && !os.IsNotExist(err) {
-			l.Warn("sendSysreportDelete", logkey.Error, err)
-		}
-	}
-	return true
-}
 
-func sendSysreportArchive(l *slog.Logger, payload feeder.SysReport, filename string, sysreportDir string, nodeID string) bool {
-	if filename == "" {
-		return false
+	name := nodeID + "-" + uuid.New().String() + ".tar"
+	fPath := filepath.Join(spoolDir, name)
+	outFile, err := os.OpenFile(fPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errSysreportSpool, err)
 	}
-	fPath := filepath.Join(sysreportDir, filename)
-
-	if !strings.HasSuffix(fPath, ".tar") {
-		return false
+	w := &errWriter{w: outFile}
+	err = checker.Copy(w, r)
+	if w.err != nil {
+		err = fmt.Errorf("%w: %w", errSysreportSpool, w.err)
 	}
-
-	reader, err := payload.File.Reader()
-	if err != nil {
-		l.Error("sendSysreportArchive", logkey.Error, err)
-		return false
+	if closeErr := outFile.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("%w: %w", errSysreportSpool, closeErr)
 	}
-	defer reader.Close()
-
-	tr := tar.NewReader(reader)
-	for {
-		header, err := tr.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			l.Error("sendSysreportArchive", logkey.Error, err)
-			return false
-		}
-		idx := strings.Index(header.Name, "/")
-		if idx == -1 {
-			continue
-		}
-		targetName := nodeID + header.Name[idx:]
-		targetPath := filepath.Join(sysreportDir, targetName)
-
-		if info, err := os.Stat(targetPath); err == nil {
-			// enable write
-			_ = os.Chmod(targetPath, info.Mode()|0200)
-		}
-
-		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
-			l.Error("sendSysreportArchive", logkey.Error, err)
-			return false
-		}
-
-		outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_RDWR|os.O_TRUNC, fs.FileMode(header.Mode))
-		if err != nil {
-			l.Error("sendSysreportArchive OpenFile", logkey.Error, err)
-			return false
-		} else {
-			defer func() {
-				_ = outFile.Close()
-			}()
-		}
-
-		if _, err := io.Copy(outFile, tr); err != nil {
-			l.Error("sendSysreportArchive Copy", logkey.Error, err)
-			return false
-		}
-
-		if info, err := os.Stat(targetPath); err == nil {
-			// restore read only
-			_ = os.Chmod(targetPath, info.Mode()|0400)
-		}
-
+	if err != nil {
+		_ = os.Remove(fPath)
+		return "", err
 	}
-	return true
+	return name, nil
 }
@@ -7,10 +7,11 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/feeder"
 	"github.com/opensvc/oc3/util/logkey"
 )
 
-func (a *Api) PostSystem(c echo.Context) error {
+func (a *Api) PostSystem(c echo.Context, params feeder.PostSystemParams) error {
 	nodeID, log := getNodeIDAndLogger(c, "PostSystem")
 	if nodeID == "" {
 		return JSONNodeAuthProblem(c)
@@ -24,6 +25,13 @@ func (a *Api) PostSystem(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedSystemP, nodeID)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
 	log.Debug("Hset FeedSystemH")
 	if _, err := a.Redis.HSet(ctx, cachekeys.FeedSystemH, nodeID, string(b)).Result(); err != nil {
 		log.Error("Hset FeedSystemH", logkey.Error, err)
@@ -35,5 +43,5 @@ func (a *Api) PostSystem(c echo.Context) error {
 		return JSONError(c)
 	}
 
-	return c.JSON(http.StatusAccepted, nil)
+	return feedJobResponse(c, w, nil)
 }
@@ -27,7 +27,7 @@ import (
 */
 
 // PutInstanceActionEnd handles PUT /feed/action
-func (a *Api) PutInstanceActionEnd(c echo.Context) error {
+func (a *Api) PutInstanceActionEnd(c echo.Context, params feeder.PutInstanceActionEndParams) error {
 	nodeID, log := getNodeIDAndLogger(c, "PutInstanceActionEnd")
 	if nodeID == "" {
 		return JSONNodeAuthProblem(c)
@@ -59,6 +59,13 @@ func (a *Api) PutInstanceActionEnd(c echo.Context) error {
 
 	idx := fmt.Sprintf("%s@%s@%s:%s", payload.Path, nodeID, ClusterID, payload.Uuid)
 
+	w, err := a.newFeedJobWaiter(ctx, log, params.Sync, cachekeys.FeedInstanceActionP, idx)
+	if err != nil {
+		log.Error("newFeedJobWaiter", logkey.Error, err)
+		return JSONError(c)
+	}
+	defer w.Close()
+
 	log.Debug("HSet keyH")
 	if _, err := a.Redis.HSet(ctx, keyH, idx, b).Result(); err != nil {
 		log.Error("HSet keyH", logkey.Error, err)
@@ -71,5 +78,5 @@ func (a *Api) PutInstanceActionEnd(c echo.Context) error {
 	}
 
 	log.Debug("action end accepted")
-	return c.JSON(http.StatusAccepted, nil)
+	return feedJobResponse(c, w, nil)
 }
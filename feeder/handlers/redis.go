@@ -8,32 +8,37 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
+var (
+	// pushNotPendingScript pushes ARGV[1] to the KEYS[2] queue if it is not
+	// already a field of the KEYS[1] pending hash, and adds it to the pending
+	// hash. The check, the pending hash update and the push are atomic.
+	// The pending hash value is the enqueue unix time in milliseconds, from
+	// the redis server clock, used by the worker for the queue latency metric.
+	// It returns 1 if the value is pushed, else 0.
+	pushNotPendingScript = redis.NewScript(`
+local t = redis.call('TIME')
+local enqueuedAt = tostring(t[1] * 1000 + math.floor(t[2] / 1000))
+if redis.call('HSETNX', KEYS[1], ARGV[1], enqueuedAt) == 0 then
+	return 0
+end
+redis.call('LPUSH', KEYS[2], ARGV[1])
+return 1
+`)
+)
+
 // pushNotPending is an alternate version of pushUniqValue that may be more efficient:
 //
 // pendingKey is a hash on elements for queueKey.
 // Consumers of queueKey must remove the pendingKey element when it pops a queueKey element.
 //
-// It uses HGET O(1) instead of LPOS O(n).
+// It uses HSETNX O(1) instead of LPOS O(n).
 // LPOS requires redis 6.0.6,
 func (a *Api) pushNotPending(ctx context.Context, log *slog.Logger, pendingKey, queueKey string, value string) error {
-	log.Debug("pushNotPending HGet pendingKey")
-	_, err := a.Redis.HGet(ctx, pendingKey, value).Result()
-	switch err {
-	case nil:
-		// already in the list
-		return nil
-	case redis.Nil:
-		// not in try push
-		log.Debug("pushNotPending HSet pendingKey")
-		if _, err := a.Redis.HSet(ctx, pendingKey, value, value).Result(); err != nil {
-			return fmt.Errorf("Hset pendingKey: %w", err)
-		}
-		log.Debug("pushNotPending LPush queueKey")
-		if _, err := a.Redis.LPush(ctx, queueKey, value).Result(); err != nil {
-			return fmt.Errorf("LPush queueKey: %w", err)
-		}
-		return nil
-	default:
-		return fmt.Errorf("HGet pendingKey: %w", err)
+	log.Debug("pushNotPending pushNotPendingScript")
+	if pushed, err := pushNotPendingScript.Run(ctx, a.Redis, []string{pendingKey, queueKey}, value).Int(); err != nil {
+		return fmt.Errorf("pushNotPendingScript: %w", err)
+	} else if pushed == 0 {
+		log.Debug("pushNotPending already pending")
 	}
+	return nil
 }
@@ -0,0 +1,32 @@
+package feederhandlers
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// specRoutes returns the SCHEMA operation routes, indexed by
+// "<method> <echo path>", where echo path is the prefixed SCHEMA path
+// converted to the echo path syntax, as returned by echo.Context.Path().
+func specRoutes(prefix string) map[string]*routers.Route {
+	m := make(map[string]*routers.Route)
+	for p, pathItem := range SCHEMA.Paths.Map() {
+		for method, op := range pathItem.Operations() {
+			m[method+" "+prefix+echoPath(p)] = &routers.Route{
+				Spec:      &SCHEMA,
+				Path:      p,
+				PathItem:  pathItem,
+				Method:    method,
+				Operation: op,
+			}
+		}
+	}
+	return m
+}
+
+// echoPath converts the openapi path parameters {name} to the echo :name syntax
+func echoPath(p string) string {
+	p = strings.ReplaceAll(p, "{", ":")
+	return strings.ReplaceAll(p, "}", "")
+}
@@ -0,0 +1,111 @@
+package feederhandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/feeder"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+type (
+	// feedJobWaiter waits for the result of a feed job, published by the
+	// worker on the feed result channel.
+	feedJobWaiter struct {
+		pubsub  *redis.PubSub
+		id      string
+		timeout time.Duration
+		log     *slog.Logger
+	}
+)
+
+const (
+	// defaultSyncTimeout is the synchronous mode timeout when Api.SyncTimeout
+	// is not set.
+	defaultSyncTimeout = 2 * time.Second
+)
+
+// newFeedJobWaiter returns a feedJobWaiter for the feed job id when the
+// synchronous mode is requested, or nil. It subscribes to the result channel,
+// so it must be called before the job is queued to not miss the result.
+func (a *Api) newFeedJobWaiter(ctx context.Context, log *slog.Logger, sync *bool, channel, id string) (*feedJobWaiter, error) {
+	if sync == nil || !*sync {
+		return nil, nil
+	}
+	w := &feedJobWaiter{
+		id:      id,
+		timeout: defaultSyncTimeout,
+		log:     log,
+	}
+	if a.SyncTimeout > 0 {
+		w.timeout = a.SyncTimeout
+	}
+	w.pubsub = a.Redis.Subscribe(ctx, channel)
+	if i, err := w.pubsub.Receive(ctx); err != nil {
+		w.Close()
+		return nil, fmt.Errorf("redis pubsub Receive: %w", err)
+	} else if _, ok := i.(*redis.Subscription); !ok {
+		w.Close()
+		return nil, fmt.Errorf("redis pubsub Receive unexpected message type %T", i)
+	}
+	return w, nil
+}
+
+// Close unsubscribes from the result channel. It is safe to call on a nil
+// feedJobWaiter.
+func (w *feedJobWaiter) Close() {
+	if w == nil {
+		return
+	}
+	if err := w.pubsub.Close(); err != nil {
+		w.log.Error("redis pubsub Close", logkey.Error, err)
+	}
+}
+
+// Wait returns the result of the feed job, or nil if it is not received
+// before the timeout.
+func (w *feedJobWaiter) Wait(ctx context.Context) *feeder.JobResult {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	for {
+		m, err := w.pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			w.log.Debug("redis pubsub ReceiveMessage", logkey.Error, err)
+			return nil
+		}
+		var result feeder.JobResult
+		if err := json.Unmarshal([]byte(m.Payload), &result); err != nil {
+			w.log.Warn("redis pubsub unexpected message", logkey.Error, err)
+			continue
+		}
+		if result.ID == w.id {
+			w.log.Debug("redis pubsub received matching id")
+			return &result
+		}
+	}
+}
+
+// feedJobResponse returns the response of a queued feed job: 202 with v if
+// w is nil or if the result is not received before the timeout, 200 with v if
+// the job succeeded, or a 500 problem with the job error if the job failed.
+func feedJobResponse(c echo.Context, w *feedJobWaiter, v any) error {
+	if w == nil {
+		return c.JSON(http.StatusAccepted, v)
+	}
+	result := w.Wait(c.Request().Context())
+	switch {
+	case result == nil:
+		return c.JSON(http.StatusAccepted, v)
+	case result.Status != "ok":
+		return JSONProblemf(c, http.StatusInternalServerError, "feed job %s: %s", result.Status, result.Error)
+	default:
+		return c.JSON(http.StatusOK, v)
+	}
+}
@@ -0,0 +1,152 @@
+package feederhandlers
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/opensvc/oc3/feeder"
+	"github.com/opensvc/oc3/util/git"
+)
+
+type (
+	// sysreportArchiveChecker verifies a sysreport tar stream while it is
+	// copied to the spool.
+	//
+	// The worker extracts the archive entries to <node dir>/<entry name without
+	// its first path element>, so an entry is rejected when:
+	//   - its name has a ".." element,
+	//   - its name has a ".git" element, in any case, which would modify the
+	//     node sysreport repository itself, like its hooks,
+	//   - it is a symlink or a hardlink whose target escapes the node directory,
+	//   - it is a device, fifo or other non file type.
+	//
+	// Links that stay inside the node directory are accepted but never
+	// materialized by the worker, so later entries can't be written through
+	// them.
+	sysreportArchiveChecker struct {
+		// maxBytes is the maximum sum of the archive file sizes, 0 means
+		// unlimited. It is the per node quota, also enforced on the node
+		// directory by the worker.
+		maxBytes int64
+
+		// maxEntries is the maximum number of archive entries, 0 means
+		// unlimited. It is the per node quota, also enforced on the node
+		// directory by the worker.
+		maxEntries int
+
+		bytes    int64
+		entries  int
+		rejected []feeder.SysReportRejectedEntry
+	}
+)
+
+var (
+	errSysreportQuota = errors.New("sysreport quota exceeded")
+)
+
+// Copy copies the tar stream r to w, verifying each entry. It returns an
+// error wrapping errSysreportQuota when the archive exceeds maxBytes or
+// maxEntries. The unsafe entries are recorded in c.rejected.
+func (c *sysreportArchiveChecker) Copy(w io.Writer, r io.Reader) error {
+	tee := io.TeeReader(r, w)
+	tr := tar.NewReader(tee)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+		c.entries++
+		if c.maxEntries > 0 && c.entries > c.maxEntries {
+			return fmt.Errorf("%w: more than %d entries", errSysreportQuota, c.maxEntries)
+		}
+		if header.Typeflag == tar.TypeReg {
+			c.bytes += header.Size
+			if c.maxBytes > 0 && c.bytes > c.maxBytes {
+				return fmt.Errorf("%w: more than %d bytes", errSysreportQuota, c.maxBytes)
+			}
+		}
+		if reason := sysreportEntryRejectReason(header); reason != "" {
+			c.reject(header.Name, reason)
+		}
+	}
+	// copy the end of archive padding
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+	return nil
+}
+
+// CheckDeleted records the unsafe deleted paths in c.rejected.
+func (c *sysreportArchiveChecker) CheckDeleted(deleted []string) {
+	for _, name := range deleted {
+		switch {
+		case hasDotDotElem(strings.TrimSpace(name)):
+			c.reject(name, "deleted path escapes the node directory")
+		case git.HasGitDirElem(name):
+			c.reject(name, "deleted path is in the repository .git directory")
+		}
+	}
+}
+
+func (c *sysreportArchiveChecker) reject(name, reason string) {
+	c.rejected = append(c.rejected, feeder.SysReportRejectedEntry{Name: name, Reason: reason})
+}
+
+// sysreportEntryRejectReason returns the reason why the entry is unsafe, or
+// "" if the entry is safe.
+func sysreportEntryRejectReason(header *tar.Header) string {
+	if hasDotDotElem(header.Name) {
+		return "name escapes the node directory"
+	}
+	if git.HasGitDirElem(header.Name) {
+		return "name is in the repository .git directory"
+	}
+	switch header.Typeflag {
+	case tar.TypeReg, tar.TypeDir:
+		return ""
+	case tar.TypeSymlink:
+		if path.IsAbs(header.Linkname) {
+			return "symlink target is absolute"
+		}
+		target := path.Join(path.Dir(sysreportEntryRelPath(header.Name)), header.Linkname)
+		if target == ".." || strings.HasPrefix(target, "../") {
+			return "symlink target escapes the node directory"
+		}
+		if git.HasGitDirElem(target) {
+			return "symlink target is in the repository .git directory"
+		}
+		return ""
+	case tar.TypeLink:
+		if hasDotDotElem(header.Linkname) {
+			return "hardlink target escapes the node directory"
+		}
+		if git.HasGitDirElem(header.Linkname) {
+			return "hardlink target is in the repository .git directory"
+		}
+		return ""
+	default:
+		return fmt.Sprintf("unsupported entry type %q", header.Typeflag)
+	}
+}
+
+// sysreportEntryRelPath returns the entry name relative to the node directory
+func sysreportEntryRelPath(name string) string {
+	_, rel, _ := strings.Cut(name, "/")
+	return rel
+}
+
+func hasDotDotElem(name string) bool {
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return true
+		}
+	}
+	return false
+}
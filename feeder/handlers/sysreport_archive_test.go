@@ -0,0 +1,85 @@
+package feederhandlers
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+)
+
+func newTestArchive(t *testing.T, headers ...*tar.Header) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	tw := tar.NewWriter(&b)
+	for _, h := range headers {
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Typeflag == tar.TypeReg {
+			if _, err := tw.Write(make([]byte, h.Size)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestSysreportArchiveCheckerRejectsGitDir(t *testing.T) {
+	cases := map[string]struct {
+		header   *tar.Header
+		rejected bool
+	}{
+		"file": {
+			header: &tar.Header{Name: "node1/file/etc/hosts", Typeflag: tar.TypeReg, Size: 4, Mode: 0644},
+		},
+		"git hook": {
+			header:   &tar.Header{Name: "node1/.git/hooks/post-commit", Typeflag: tar.TypeReg, Size: 4, Mode: 0755},
+			rejected: true,
+		},
+		"git config": {
+			header:   &tar.Header{Name: "node1/.git/config", Typeflag: tar.TypeReg, Size: 4, Mode: 0644},
+			rejected: true,
+		},
+		"git dir upper case": {
+			header:   &tar.Header{Name: "node1/.GIT/hooks/pre-commit", Typeflag: tar.TypeReg, Size: 4, Mode: 0755},
+			rejected: true,
+		},
+		"nested git dir": {
+			header:   &tar.Header{Name: "node1/file/srv/app/.git/config", Typeflag: tar.TypeReg, Size: 4, Mode: 0644},
+			rejected: true,
+		},
+		"git dir entry": {
+			header:   &tar.Header{Name: "node1/.git/", Typeflag: tar.TypeDir, Mode: 0755},
+			rejected: true,
+		},
+		"symlink to git dir": {
+			header:   &tar.Header{Name: "node1/file/hooks", Typeflag: tar.TypeSymlink, Linkname: "../.git/hooks"},
+			rejected: true,
+		},
+		"dot git prefix": {
+			header: &tar.Header{Name: "node1/file/etc/.gitconfig", Typeflag: tar.TypeReg, Size: 4, Mode: 0644},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &sysreportArchiveChecker{}
+			if err := c.Copy(io.Discard, bytes.NewReader(newTestArchive(t, tc.header))); err != nil {
+				t.Fatal(err)
+			}
+			if got := len(c.rejected) > 0; got != tc.rejected {
+				t.Fatalf("%s: rejected %v, expected %v: %v", tc.header.Name, got, tc.rejected, c.rejected)
+			}
+		})
+	}
+}
+
+func TestSysreportArchiveCheckerRejectsGitDirDeleted(t *testing.T) {
+	c := &sysreportArchiveChecker{}
+	c.CheckDeleted([]string{"/etc/hosts", ".git/index", "/srv/app/.Git/config"})
+	if len(c.rejected) != 2 {
+		t.Fatalf("expected 2 rejected deleted paths, got %v", c.rejected)
+	}
+}
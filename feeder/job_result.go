@@ -0,0 +1,14 @@
+package feeder
+
+// JobResult is the result of a feed job, published by the worker on the feed
+// result channel for the handlers running in synchronous mode.
+type JobResult struct {
+	// ID is the job index, as pushed to the feed queue
+	ID string `json:"id"`
+
+	// Status is the job status: ok or failed
+	Status string `json:"status"`
+
+	// Error is the job error text of a failed job
+	Error string `json:"error,omitempty"`
+}
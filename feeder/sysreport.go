@@ -0,0 +1,15 @@
+package feeder
+
+// SysreportData is the FeedSysreportQ value pushed by the feeder and
+// consumed by the worker sysreport job.
+type SysreportData struct {
+	NodeID string `json:"node_id"`
+
+	// Archive is the spooled tar archive file name, relative to the
+	// sysreport spool directory.
+	Archive string `json:"archive,omitempty"`
+
+	// Deleted is the list of files removed from the node since the previous
+	// sysreport.
+	Deleted []string `json:"deleted,omitempty"`
+}
@@ -10,6 +10,7 @@ import (
 	"net"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -40,7 +41,12 @@ type (
 	Worker struct {
 		dispatchC chan cdb.ActionQueueEntry
 		cmdC      chan any
-		ctx       context.Context
+
+		// ctx is the context of the action executions
+		ctx context.Context
+
+		// stop is closed when the worker must stop taking new actions
+		stop <-chan struct{}
 	}
 
 	cmdSetUnreachable struct {
@@ -84,6 +90,7 @@ const (
 	DefaultNotificationTimeout = 5 * time.Second
 	DefaultCommandTimeout      = 10 * time.Second
 	DefaultPurgeTimeout        = 24 * time.Hour
+	DefaultDrainTimeout        = 30 * time.Second
 )
 
 var (
@@ -131,19 +138,37 @@ var (
 		}, []string{"op"})
 )
 
+// Run dispatches the waiting actions to the workers until d.Ctx is done.
+// Then it stops dispatching, waits for the running actions to finish,
+// flushes the batched status updates and returns. The actions still
+// running after the runner.drain_timeout are canceled.
 func (d *ActionDaemon) Run() error {
 	nbWorkers := getOptionInt("runner.nb_workers", DefaultNbWorkers)
 	purgeTimeout := getOptionDuration("runner.purge_timeout", DefaultPurgeTimeout)
+	drainTimeout := getOptionDuration("runner.drain_timeout", DefaultDrainTimeout)
 	odb := cdb.New(d.DB)
 	dispatchC := make(chan cdb.ActionQueueEntry)
 	cmdC := make(chan any)
+
+	// workCtx and dbCtx outlive d.Ctx during the drain, so the running
+	// actions can finish and their status updates can be flushed.
+	dbCtx := context.WithoutCancel(d.Ctx)
+	workCtx, cancelWork := context.WithCancel(dbCtx)
+	defer cancelWork()
+
+	var workers sync.WaitGroup
 	for i := 0; i < nbWorkers; i++ {
 		w := Worker{
 			dispatchC: dispatchC,
 			cmdC:      cmdC,
-			ctx:       d.Ctx,
+			ctx:       workCtx,
+			stop:      d.Ctx.Done(),
 		}
-		go w.Run()
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			w.Run()
+		}()
 	}
 
 	// pollTicker for polling waiting actions
@@ -190,13 +215,34 @@ func (d *ActionDaemon) Run() error {
 		getQueuedErrorLogger.reset()
 
 		// dispatch each action to a worker
-		for _, line := range lines {
-			dispatchC <- line
+		for i, line := range lines {
+			select {
+			case dispatchC <- line:
+			case <-d.Ctx.Done():
+				// give the undispatched actions back to the next runner
+				ids := make([]int, 0, len(lines)-i)
+				for _, line := range lines[i:] {
+					ids = append(ids, line.ID)
+				}
+				dbRequests.WithLabelValues("reset_dequeued").Inc()
+				if err := odb.ActionQResetDequeued(dbCtx, ids); err != nil {
+					slog.Warn(fmt.Sprintf("reset dequeued: %s", err))
+					dbErrors.WithLabelValues("reset_dequeued").Inc()
+				}
+				return
+			}
 		}
 	}
+
+	var (
+		pollC      = pollTicker.C
+		stopC      = d.Ctx.Done()
+		drainC     <-chan time.Time
+		workersEnd = make(chan struct{})
+	)
 	for {
 		select {
-		case <-pollTicker.C:
+		case <-pollC:
 			pollWaitingActions()
 		case cmd := <-cmdC:
 			switch cmd.(type) {
@@ -225,99 +271,119 @@ func (d *ActionDaemon) Run() error {
 				actionProcessed.WithLabelValues(c.actionType, result).Inc()
 			}
 		case <-updateTicker.C:
-			if len(d.unreachableIds) > 0 {
-				err := odb.ActionQSetUnreachable(d.Ctx, d.unreachableIds)
-				dbRequests.WithLabelValues("set_unreachable").Inc()
-				if err != nil {
-					slog.Warn(fmt.Sprintf("set unreachable: %s", err))
-					dbErrors.WithLabelValues("set_unreachable").Inc()
-				} else {
-					slog.Debug(fmt.Sprintf("set unreachable: %v", d.unreachableIds))
-					d.unreachableIds = []int{}
-				}
-			}
-			if len(d.invalidIds) > 0 {
-				err := odb.ActionQSetInvalid(d.Ctx, d.invalidIds)
-				dbRequests.WithLabelValues("set_invalid").Inc()
-				if err != nil {
-					slog.Warn(fmt.Sprintf("set invalid: %s", err))
-					dbErrors.WithLabelValues("set_invalid").Inc()
-				} else {
-					slog.Debug(fmt.Sprintf("set invalid: %v", d.invalidIds))
-					d.invalidIds = []int{}
-				}
-			}
-			if len(d.nIds) > 0 {
-				err := odb.ActionQSetNotified(d.Ctx, d.nIds)
-				dbRequests.WithLabelValues("set_notified").Inc()
-				if err != nil {
-					slog.Warn(fmt.Sprintf("set notified: %s", err))
-					dbErrors.WithLabelValues("set_notified").Inc()
-				} else {
-					slog.Debug(fmt.Sprintf("set notified: %v", d.nIds))
-					d.nIds = []int{}
-				}
-			}
-			if len(d.ids) > 0 {
-				err := odb.ActionQSetQueued(d.Ctx, d.ids)
-				dbRequests.WithLabelValues("set_queued").Inc()
-				if err != nil {
-					slog.Warn(fmt.Sprintf("set queued: %s", err))
-					dbErrors.WithLabelValues("set_queued").Inc()
-				} else {
-					slog.Debug(fmt.Sprintf("set queued: %v", d.ids))
-					d.ids = []int{}
-				}
-			}
-			if len(d.runningIds) > 0 {
-				err := odb.ActionQSetRunning(d.Ctx, d.runningIds)
-				dbRequests.WithLabelValues("set_running").Inc()
-				if err != nil {
-					slog.Warn(fmt.Sprintf("set running: %s", err))
-					dbErrors.WithLabelValues("set_running").Inc()
-				} else {
-					slog.Debug(fmt.Sprintf("set running: %v", d.runningIds))
-					d.runningIds = []int{}
-				}
-			}
-			if len(d.doneEntries) > 0 {
-				for _, entry := range d.doneEntries {
-					err := odb.ActionQSetDone(d.Ctx, entry.id, entry.ret, entry.stdout, entry.stderr)
-					dbRequests.WithLabelValues("set_done").Inc()
-					if err != nil {
-						slog.Warn(fmt.Sprintf("set done: %s", err))
-						dbErrors.WithLabelValues("set_done").Inc()
-					} else {
-						slog.Debug(fmt.Sprintf("set done: id %d ret %d stdout %d stderr %d", entry.id, entry.ret, len(entry.stdout), len(entry.stderr)))
-					}
-				}
-				d.doneEntries = []cmdSetDone{}
-			}
-			if len(d.ids) > 0 || len(d.nIds) > 0 || len(d.invalidIds) > 0 || len(d.unreachableIds) > 0 || len(d.runningIds) > 0 || len(d.doneEntries) > 0 {
-				data, err := odb.ActionQEventData(d.Ctx)
-				dbRequests.WithLabelValues("action_queue_event_data").Inc()
-				if err != nil {
-					slog.Warn(fmt.Sprintf("get action queue event data: %s", err))
-					dbErrors.WithLabelValues("action_queue_event_data").Inc()
-				}
-				if err := odb.Session.NotifyTableChangeWithData(d.Ctx, "action_queue", data); err != nil {
-					slog.Warn(fmt.Sprintf("notify changes: %s", err))
-				}
-			}
+			d.flushUpdates(dbCtx, odb)
 		case <-purgeTicker.C:
-			if err := odb.ActionQPurge(d.Ctx); err != nil {
+			if err := odb.ActionQPurge(dbCtx); err != nil {
 				slog.Warn(fmt.Sprintf("purge action queue: %s", err))
 				dbErrors.WithLabelValues("purge").Inc()
 			} else {
 				slog.Debug("purge action queue: done")
 			}
 			dbRequests.WithLabelValues("purge").Inc()
-		case <-d.Ctx.Done():
+		case <-stopC:
+			slog.Info(fmt.Sprintf("stopped dispatching, drain the running actions with timeout %s", drainTimeout))
+			pollC = nil
+			stopC = nil
+			drainC = time.After(drainTimeout)
+			go func() {
+				workers.Wait()
+				close(workersEnd)
+			}()
+		case <-drainC:
+			slog.Warn("drain timeout, cancel the running actions")
+			cancelWork()
+		case <-workersEnd:
+			d.flushUpdates(dbCtx, odb)
+			slog.Info("drained")
 			return nil
 		}
 	}
 }
 
+// flushUpdates applies the batched action status updates to the database,
+// and notifies the action_queue table change.
+func (d *ActionDaemon) flushUpdates(ctx context.Context, odb *cdb.DB) {
+	if len(d.unreachableIds) > 0 {
+		err := odb.ActionQSetUnreachable(ctx, d.unreachableIds)
+		dbRequests.WithLabelValues("set_unreachable").Inc()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("set unreachable: %s", err))
+			dbErrors.WithLabelValues("set_unreachable").Inc()
+		} else {
+			slog.Debug(fmt.Sprintf("set unreachable: %v", d.unreachableIds))
+			d.unreachableIds = []int{}
+		}
+	}
+	if len(d.invalidIds) > 0 {
+		err := odb.ActionQSetInvalid(ctx, d.invalidIds)
+		dbRequests.WithLabelValues("set_invalid").Inc()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("set invalid: %s", err))
+			dbErrors.WithLabelValues("set_invalid").Inc()
+		} else {
+			slog.Debug(fmt.Sprintf("set invalid: %v", d.invalidIds))
+			d.invalidIds = []int{}
+		}
+	}
+	if len(d.nIds) > 0 {
+		err := odb.ActionQSetNotified(ctx, d.nIds)
+		dbRequests.WithLabelValues("set_notified").Inc()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("set notified: %s", err))
+			dbErrors.WithLabelValues("set_notified").Inc()
+		} else {
+			slog.Debug(fmt.Sprintf("set notified: %v", d.nIds))
+			d.nIds = []int{}
+		}
+	}
+	if len(d.ids) > 0 {
+		err := odb.ActionQSetQueued(ctx, d.ids)
+		dbRequests.WithLabelValues("set_queued").Inc()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("set queued: %s", err))
+			dbErrors.WithLabelValues("set_queued").Inc()
+		} else {
+			slog.Debug(fmt.Sprintf("set queued: %v", d.ids))
+			d.ids = []int{}
+		}
+	}
+	if len(d.runningIds) > 0 {
+		err := odb.ActionQSetRunning(ctx, d.runningIds)
+		dbRequests.WithLabelValues("set_running").Inc()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("set running: %s", err))
+			dbErrors.WithLabelValues("set_running").Inc()
+		} else {
+			slog.Debug(fmt.Sprintf("set running: %v", d.runningIds))
+			d.runningIds = []int{}
+		}
+	}
+	if len(d.doneEntries) > 0 {
+		for _, entry := range d.doneEntries {
+			err := odb.ActionQSetDone(ctx, entry.id, entry.ret, entry.stdout, entry.stderr)
+			dbRequests.WithLabelValues("set_done").Inc()
+			if err != nil {
+				slog.Warn(fmt.Sprintf("set done: %s", err))
+				dbErrors.WithLabelValues("set_done").Inc()
+			} else {
+				slog.Debug(fmt.Sprintf("set done: id %d ret %d stdout %d stderr %d", entry.id, entry.ret, len(entry.stdout), len(entry.stderr)))
+			}
+		}
+		d.doneEntries = []cmdSetDone{}
+	}
+	if len(d.ids) > 0 || len(d.nIds) > 0 || len(d.invalidIds) > 0 || len(d.unreachableIds) > 0 || len(d.runningIds) > 0 || len(d.doneEntries) > 0 {
+		data, err := odb.ActionQEventData(ctx)
+		dbRequests.WithLabelValues("action_queue_event_data").Inc()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("get action queue event data: %s", err))
+			dbErrors.WithLabelValues("action_queue_event_data").Inc()
+		}
+		if err := odb.Session.NotifyTableChangeWithData(ctx, "action_queue", data); err != nil {
+			slog.Warn(fmt.Sprintf("notify changes: %s", err))
+		}
+	}
+}
+
 func (w *Worker) Run() {
 	for {
 		select {
@@ -325,7 +391,7 @@ func (w *Worker) Run() {
 			if err := w.work(e); err != nil {
 				slog.Warn(err.Error())
 			}
-		case <-w.ctx.Done():
+		case <-w.stop:
 			return
 		}
 	}
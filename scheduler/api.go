@@ -0,0 +1,304 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/util/version"
+)
+
+type (
+	// TaskInfo is the task description returned by the scheduler api.
+	TaskInfo struct {
+		Name string `json:"name"`
+
+		// State is one of disabled, scheduled, running and stopped for the
+		// tasks, running and idle for the children.
+		State string `json:"state"`
+
+		IsDisabled bool   `json:"is_disabled"`
+		Schedule   string `json:"schedule,omitempty"`
+
+		// LastRunAt is the last execution begin by any scheduler for the
+		// tasks, by this scheduler for the children.
+		LastRunAt *time.Time `json:"last_run_at,omitempty"`
+
+		// LastDuration and LastError describe the last execution by this
+		// scheduler.
+		LastDuration string `json:"last_duration,omitempty"`
+		LastError    string `json:"last_error,omitempty"`
+
+		// Parallel is the maximum number of children executed at the
+		// same time.
+		Parallel int `json:"parallel,omitempty"`
+
+		// DependsOn is the names of the sibling children executed before
+		// the child.
+		DependsOn []string `json:"depends_on,omitempty"`
+
+		Children []TaskInfo `json:"children,omitempty"`
+	}
+
+	// apiProblem is the api error response, like the server Problem.
+	apiProblem struct {
+		Text string `json:"text"`
+	}
+)
+
+const (
+	TaskStateDisabled  = "disabled"
+	TaskStateScheduled = "scheduled"
+	TaskStateRunning   = "running"
+	TaskStateStopped   = "stopped"
+	TaskStateIdle      = "idle"
+)
+
+// RegisterHandlersWithBaseURL adds the scheduler api handlers to e:
+//
+//	GET  <baseURL>/version
+//	GET  <baseURL>/tasks
+//	GET  <baseURL>/tasks/:name
+//	POST <baseURL>/tasks/:name/enable
+//	POST <baseURL>/tasks/:name/disable
+//	POST <baseURL>/tasks/:name/trigger
+//	POST <baseURL>/tasks/:name/cancel
+//
+// Except version, the handlers require a user of the Manager group.
+func (t *Scheduler) RegisterHandlersWithBaseURL(e *echo.Echo, baseURL string) {
+	t.initRuntimes()
+	e.GET(baseURL+"/version", t.getVersion)
+	g := e.Group(baseURL+"/tasks", managerMiddleware)
+	g.GET("", t.getTasks)
+	g.GET("/:name", t.getTask)
+	g.POST("/:name/enable", t.postTaskEnable)
+	g.POST("/:name/disable", t.postTaskDisable)
+	g.POST("/:name/trigger", t.postTaskTrigger)
+	g.POST("/:name/cancel", t.postTaskCancel)
+}
+
+// managerMiddleware denies the requests of the users not in the Manager
+// group, set in the "groups" context key by the auth middleware.
+func managerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		groups, _ := c.Get("groups").([]string)
+		if !slices.Contains(groups, "Manager") {
+			return apiProblemf(c, http.StatusForbidden, "the Manager group membership is required")
+		}
+		return next(c)
+	}
+}
+
+func apiProblemf(c echo.Context, code int, format string, args ...any) error {
+	return c.JSON(code, apiProblem{Text: fmt.Sprintf(format, args...)})
+}
+
+func (t *Scheduler) getVersion(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{
+		"version": version.Version(),
+		"service": "scheduler",
+	})
+}
+
+// getTasks handles GET /tasks
+func (t *Scheduler) getTasks(c echo.Context) error {
+	states, err := t.GetStateMap(c.Request().Context())
+	if err != nil {
+		t.Errorf("api: get tasks states: %s", err)
+		return apiProblemf(c, http.StatusInternalServerError, "cannot get the tasks states")
+	}
+	l := make([]TaskInfo, 0, len(Tasks))
+	for _, task := range Tasks {
+		l = append(l, t.taskInfo(task, states[task.name]))
+	}
+	return c.JSON(http.StatusOK, map[string]any{"data": l})
+}
+
+// getTask handles GET /tasks/:name
+func (t *Scheduler) getTask(c echo.Context) error {
+	task, ok := t.apiTask(c)
+	if !ok {
+		return apiProblemf(c, http.StatusNotFound, "task %s not found", c.Param("name"))
+	}
+	return t.replyTask(c, http.StatusOK, task)
+}
+
+// postTaskEnable handles POST /tasks/:name/enable
+func (t *Scheduler) postTaskEnable(c echo.Context) error {
+	return t.setTaskDisabled(c, false)
+}
+
+// postTaskDisable handles POST /tasks/:name/disable
+func (t *Scheduler) postTaskDisable(c echo.Context) error {
+	return t.setTaskDisabled(c, true)
+}
+
+func (t *Scheduler) setTaskDisabled(c echo.Context, disabled bool) error {
+	name := c.Param("name")
+	task, ok := t.apiTask(c)
+	if !ok {
+		if _, ok := t.runtimes[name]; ok {
+			return apiProblemf(c, http.StatusBadRequest, "task %s is a child task, its parent task can be enabled or disabled", name)
+		}
+		return apiProblemf(c, http.StatusNotFound, "task %s not found", name)
+	}
+	if err := t.setDisabled(c.Request().Context(), name, disabled); err != nil {
+		t.Errorf("api: %s", err)
+		return apiProblemf(c, http.StatusInternalServerError, "cannot store the task %s disabled state", name)
+	}
+	if disabled {
+		t.Infof("api: task %s disabled", name)
+	} else {
+		t.Infof("api: task %s enabled", name)
+	}
+	return t.replyTask(c, http.StatusOK, task)
+}
+
+// postTaskTrigger handles POST /tasks/:name/trigger
+func (t *Scheduler) postTaskTrigger(c echo.Context) error {
+	name := c.Param("name")
+	task, ok := t.apiTask(c)
+	if !ok {
+		if _, ok := t.runtimes[name]; ok {
+			return apiProblemf(c, http.StatusBadRequest, "task %s is a child task, its parent task can be triggered", name)
+		}
+		return apiProblemf(c, http.StatusNotFound, "task %s not found", name)
+	}
+	rt := t.runtimes[name]
+	rt.Lock()
+	started, running, lease := rt.started, rt.running, rt.lease
+	rt.Unlock()
+	switch {
+	case !started:
+		return apiProblemf(c, http.StatusConflict, "task %s is not scheduled by this scheduler", name)
+	case running:
+		return apiProblemf(c, http.StatusConflict, "task %s is already running", name)
+	}
+	if lease != nil {
+		// hold the lease before accepting, so the triggered execution
+		// is not skipped for a peer scheduler holding the lease.
+		peer, err := lease.hold(c.Request().Context())
+		if err != nil {
+			t.Errorf("api: trigger %s: %s", name, err)
+			return apiProblemf(c, http.StatusInternalServerError, "cannot acquire the task %s lease", name)
+		}
+		if peer != "" {
+			return apiProblemf(c, http.StatusConflict, "task %s lease held by %s", name, peer)
+		}
+	}
+	select {
+	case rt.trigger <- struct{}{}:
+		t.Infof("api: task %s triggered", name)
+	default:
+		// a trigger is already pending
+	}
+	return t.replyTask(c, http.StatusAccepted, task)
+}
+
+// postTaskCancel handles POST /tasks/:name/cancel
+func (t *Scheduler) postTaskCancel(c echo.Context) error {
+	name := c.Param("name")
+	rt, ok := t.runtimes[name]
+	if !ok {
+		return apiProblemf(c, http.StatusNotFound, "task %s not found", name)
+	}
+	rt.Lock()
+	cancel := rt.cancel
+	rt.Unlock()
+	if cancel == nil {
+		return apiProblemf(c, http.StatusConflict, "task %s is not running on this scheduler", name)
+	}
+	cancel()
+	t.Infof("api: task %s execution canceled", name)
+	return c.JSON(http.StatusAccepted, map[string]string{"name": name, "state": TaskStateRunning})
+}
+
+// apiTask returns the task named after the name path parameter. Only the
+// tasks are found, not the children.
+func (t *Scheduler) apiTask(c echo.Context) (Task, bool) {
+	name := c.Param("name")
+	for _, task := range Tasks {
+		if task.name == name {
+			return task, true
+		}
+	}
+	return Task{}, false
+}
+
+func (t *Scheduler) replyTask(c echo.Context, code int, task Task) error {
+	task.SetDB(t.DB)
+	state, err := task.GetState(c.Request().Context())
+	if err != nil {
+		t.Errorf("api: get task %s state: %s", task.name, err)
+		return apiProblemf(c, http.StatusInternalServerError, "cannot get the task %s state", task.name)
+	}
+	return c.JSON(code, t.taskInfo(task, state))
+}
+
+func (t *Scheduler) taskInfo(task Task, state State) TaskInfo {
+	info := TaskInfo{
+		Name:       task.name,
+		IsDisabled: state.IsDisabled,
+	}
+	// the schedules are validated by Run
+	if schedule, err := loadTaskSchedule(task.name); err == nil {
+		info.Schedule = schedule.describe(task.period)
+	}
+	if !state.LastRunAt.IsZero() {
+		info.LastRunAt = &state.LastRunAt
+	}
+	rt := t.runtimes[task.name]
+	rt.Lock()
+	switch {
+	case rt.running:
+		info.State = TaskStateRunning
+	case state.IsDisabled:
+		info.State = TaskStateDisabled
+	case rt.started:
+		info.State = TaskStateScheduled
+	default:
+		info.State = TaskStateStopped
+	}
+	rt.fill(&info)
+	rt.Unlock()
+
+	for _, child := range task.children {
+		name := childName(task.name, child.name)
+		childInfo := TaskInfo{Name: name, State: TaskStateIdle, DependsOn: child.dependsOn}
+		rt := t.runtimes[name]
+		rt.Lock()
+		if rt.running {
+			childInfo.State = TaskStateRunning
+		}
+		if !rt.lastRunAt.IsZero() {
+			lastRunAt := rt.lastRunAt
+			childInfo.LastRunAt = &lastRunAt
+		}
+		rt.fill(&childInfo)
+		rt.Unlock()
+		info.Children = append(info.Children, childInfo)
+	}
+	if len(task.children) > 0 {
+		info.Parallel = task.parallelism()
+	}
+	return info
+}
+
+// setDisabled stores the task disabled state. The schedulers start or stop
+// the task on their next state poll.
+func (t *Scheduler) setDisabled(ctx context.Context, name string, disabled bool) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	query := "INSERT INTO oc3_scheduler (task_name, is_disabled) VALUES (?, ?) ON DUPLICATE KEY UPDATE is_disabled = VALUES(is_disabled)"
+
+	_, err := t.DB.ExecContext(ctx, query, name, disabled)
+	if err != nil {
+		return fmt.Errorf("set %s disabled %v: %w", name, disabled, err)
+	}
+	return nil
+}
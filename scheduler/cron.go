@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// cronSpec is a 5 fields cron expression: minute, hour, day of month,
+	// month and day of week. The fields accept "*", values, ranges "a-b",
+	// steps "*/n" or "a-b/n" and comma separated lists. When both the day
+	// of month and the day of week are restricted, a day matching one of
+	// them matches, like in crontab(5). A field beginning with "*", like
+	// "*/2", is not restricted.
+	cronSpec struct {
+		expr    string
+		minute  uint64
+		hour    uint64
+		dom     uint64
+		month   uint64
+		dow     uint64
+		domStar bool
+		dowStar bool
+	}
+
+	cronField struct {
+		name     string
+		min, max int
+	}
+)
+
+var (
+	cronFields = []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12},
+		{name: "day of week", min: 0, max: 7},
+	}
+
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// parseCron parses a 5 fields cron expression, or one of the @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight and @hourly macros.
+func parseCron(expr string) (*cronSpec, error) {
+	s := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[s]; ok {
+		s = macro
+	}
+	l := strings.Fields(s)
+	if len(l) != len(cronFields) {
+		return nil, fmt.Errorf("cron %q: expected %d fields, got %d", expr, len(cronFields), len(l))
+	}
+	c := &cronSpec{expr: expr}
+	bits := make([]uint64, len(cronFields))
+	for i, field := range cronFields {
+		b, err := field.parse(l[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+	c.minute, c.hour, c.dom, c.month, c.dow = bits[0], bits[1], bits[2], bits[3], bits[4]
+	// 7 is sunday, like 0
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = strings.HasPrefix(l[2], "*")
+	c.dowStar = strings.HasPrefix(l[4], "*")
+	return c, nil
+}
+
+// parse returns the bitset of the field values matching s.
+func (f cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: invalid step %q", f.name, stepPart)
+			}
+			step = n
+		}
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = f.value(a); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(b); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s: invalid range %q", f.name, rangePart)
+			}
+		default:
+			v, err := f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			low = v
+			if !hasStep {
+				high = v
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s: invalid value %q, expected %d-%d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// next returns the first time strictly after t matching the expression, in
+// the t location. It returns the zero time if no time matches in the next
+// 5 years, like "0 0 31 2 *". The wall clock times skipped by a DST change
+// don't match, and the wall clock times repeated by a DST change match once.
+func (c *cronSpec) next(t time.Time) time.Time {
+	loc := t.Location()
+	wall := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	}
+	from := wall(t)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !wall(t).After(from):
+			// repeated wall clock time
+			t = t.Add(time.Minute)
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatch(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *cronSpec) dayMatch(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (c *cronSpec) String() string {
+	return c.expr
+}
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseCron(t *testing.T) {
+	cases := map[string]bool{
+		"* * * * *":        true,
+		"*/15 1-5 * * 1-5": true,
+		"0 0 */2 * *":      true,
+		"0 0 * * */2":      true,
+		"0 0 1,15 * 0,7":   true,
+		"@daily":           true,
+		"@weekly":          true,
+		"* * * *":          false,
+		"60 * * * *":       false,
+		"* 24 * * *":       false,
+		"* * 0 * *":        false,
+		"* * * 13 *":       false,
+		"* * * * 8":        false,
+		"*/0 * * * *":      false,
+		"5-1 * * * *":      false,
+		"@sometimes":       false,
+	}
+	for expr, valid := range cases {
+		_, err := parseCron(expr)
+		if valid && err != nil {
+			t.Errorf("%q: unexpected error: %s", expr, err)
+		} else if !valid && err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2026-01-01 is a thursday
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"*/15 * * * *", from.Add(7 * time.Minute), time.Date(2026, 1, 1, 0, 15, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{"0 0 */2 * *", from, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * */2", from, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		// a day of month step is not a restriction: the odd mondays
+		{"0 0 */2 * 1", from, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */2 * 1", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC)},
+		// both restricted: the first of the month or the mondays
+		{"0 0 1 * 1", from, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 1", time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", from, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", from, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", from, time.Time{}},
+	}
+	for _, tc := range cases {
+		c, err := parseCron(tc.expr)
+		if err != nil {
+			t.Fatalf("%q: %s", tc.expr, err)
+		}
+		if got := c.next(tc.from); !got.Equal(tc.want) {
+			t.Errorf("%q next after %s: got %s, expected %s", tc.expr, tc.from, got, tc.want)
+		}
+	}
+}
+
+func TestCronNextDST(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "spring forward skips the missing time",
+			expr: "30 2 * * *",
+			from: time.Date(2026, 3, 28, 3, 0, 0, 0, paris),
+			want: time.Date(2026, 3, 30, 2, 30, 0, 0, paris),
+		},
+		{
+			name: "spring forward steps over the missing hour",
+			expr: "*/30 * * * *",
+			from: time.Date(2026, 3, 29, 1, 45, 0, 0, paris),
+			want: time.Date(2026, 3, 29, 3, 0, 0, 0, paris),
+		},
+		{
+			// 02:30 CEST, the first 02:30 of the day
+			name: "fall back matches the repeated time once",
+			expr: "30 2 * * *",
+			from: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC).In(paris),
+			want: time.Date(2026, 10, 26, 2, 30, 0, 0, paris),
+		},
+		{
+			// 02:00 CEST, the repeated hour is skipped to 03:00 CET
+			name: "fall back hourly",
+			expr: "0 * * * *",
+			from: time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC).In(paris),
+			want: time.Date(2026, 10, 25, 2, 0, 0, 0, time.UTC).In(paris),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := parseCron(tc.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.next(tc.from); !got.Equal(tc.want) {
+				t.Errorf("%q next after %s: got %s, expected %s", tc.expr, tc.from, got, tc.want)
+			}
+		})
+	}
+}
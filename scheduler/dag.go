@@ -0,0 +1,231 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+type (
+	// childResult is the result of a child execution.
+	childResult struct {
+		index int
+		err   error
+		rows  int64
+		known bool
+	}
+)
+
+// parallelism returns the maximum number of children executed at the same
+// time.
+func (t *Task) parallelism() int {
+	n := viper.GetInt("scheduler.task." + t.name + ".parallel")
+	if n <= 0 {
+		n = t.parallel
+	}
+	if n <= 0 {
+		n = 1
+	}
+	return n
+}
+
+// validateChildren returns an error if a child depends on an unknown
+// sibling, or if the dependencies have a cycle.
+func (t *Task) validateChildren() error {
+	index := make(map[string]int)
+	for i, child := range t.children {
+		if _, ok := index[child.name]; ok {
+			return fmt.Errorf("%s: duplicate child %s", t.name, child.name)
+		}
+		index[child.name] = i
+	}
+	for _, child := range t.children {
+		for _, dep := range child.dependsOn {
+			if _, ok := index[dep]; !ok {
+				return fmt.Errorf("%s: child %s depends on unknown child %s", t.name, child.name, dep)
+			}
+		}
+	}
+	if _, err := t.childDepths(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// childDepths returns the length of the longest dependency path of each
+// child, 0 for the children without dependency.
+func (t *Task) childDepths() ([]int, error) {
+	index := make(map[string]int)
+	for i, child := range t.children {
+		index[child.name] = i
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make([]int, len(t.children))
+	depths := make([]int, len(t.children))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch marks[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%s: dependency cycle on child %s", t.name, t.children[i].name)
+		}
+		marks[i] = visiting
+		for _, dep := range t.children[i].dependsOn {
+			j, ok := index[dep]
+			if !ok {
+				continue
+			}
+			if err := visit(j); err != nil {
+				return err
+			}
+			depths[i] = max(depths[i], depths[j]+1)
+		}
+		marks[i] = visited
+		return nil
+	}
+	for i := range t.children {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return depths, nil
+}
+
+// execChildren executes the children, at most parallelism at the same time,
+// in the declaration order once their dependencies are successful. The
+// children depending on a failed child are skipped.
+func (t *Task) execChildren(ctx context.Context) error {
+	if err := t.validateChildren(); err != nil {
+		return err
+	}
+	index := make(map[string]int)
+	for i, child := range t.children {
+		index[child.name] = i
+	}
+	n := len(t.children)
+	parallel := t.parallelism()
+	errs := make([]error, n)
+	done := make([]bool, n)
+	pending := make([]int, n)
+	for i := range pending {
+		pending[i] = i
+	}
+	results := make(chan childResult)
+	running := 0
+
+	start := func(i int) {
+		child := t.children[i]
+		child.db = t.db
+		child.ev = t.ev
+		child.session = t.session
+		child.runtimes = t.runtimes
+		child.name = childName(t.name, child.name)
+		if child.timeout == 0 {
+			child.timeout = t.timeout
+		}
+		running++
+		go func() {
+			err := child.Exec(ctx)
+			rows, known := child.rows.get()
+			results <- childResult{index: i, err: err, rows: rows, known: known}
+		}()
+	}
+
+	// ready returns true if the child dependencies are done, and the
+	// name of a failed dependency if any.
+	ready := func(i int) (bool, string) {
+		for _, dep := range t.children[i].dependsOn {
+			j := index[dep]
+			if !done[j] {
+				return false, ""
+			}
+			if errs[j] != nil {
+				return true, dep
+			}
+		}
+		return true, ""
+	}
+
+	for len(pending) > 0 || running > 0 {
+		for k := 0; k < len(pending) && running < parallel; {
+			i := pending[k]
+			ok, failedDep := ready(i)
+			switch {
+			case !ok:
+				k++
+				continue
+			case ctx.Err() != nil:
+				errs[i] = fmt.Errorf("%s: skipped: %w", childName(t.name, t.children[i].name), ctx.Err())
+				done[i] = true
+			case failedDep != "":
+				errs[i] = fmt.Errorf("%s: skipped: dependency %s failed", childName(t.name, t.children[i].name), failedDep)
+				done[i] = true
+				t.Warnf("%s", errs[i])
+			default:
+				start(i)
+			}
+			pending = slices.Delete(pending, k, k+1)
+			// a skipped child can make others ready, rescan
+			k = 0
+		}
+		if running == 0 {
+			// not reached with validated dependencies
+			break
+		}
+		r := <-results
+		running--
+		done[r.index] = true
+		errs[r.index] = r.err
+		if r.known {
+			t.AddRowsAffected(r.rows)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fprintChildren writes the children dependency graph: each child is
+// indented by the length of its longest dependency path, and followed by
+// its dependencies.
+func (t *Task) fprintChildren(w io.Writer) {
+	depths, err := t.childDepths()
+	if err != nil {
+		fmt.Fprintln(w, "  error: "+err.Error())
+		depths = make([]int, len(t.children))
+	}
+	order := make([]int, len(t.children))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return depths[a] - depths[b]
+	})
+	for _, i := range order {
+		child := t.children[i]
+		line := strings.Repeat("  ", depths[i]+1) + child.name
+		if len(child.dependsOn) > 0 {
+			line += " <- " + strings.Join(child.dependsOn, ", ")
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// Validate returns an error if the children dependencies of a task are
+// invalid.
+func (t TaskList) Validate() error {
+	for _, task := range t {
+		if err := task.validateChildren(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/opensvc/oc3/cachekeys"
+)
+
+type (
+	// taskLease is the redis lease of a task, held by the scheduler
+	// executing the task. The lease outlives the task period by a grace
+	// delay, so the holder renews it on its next execution before a peer
+	// scheduler can acquire it. A peer acquires the lease when the holder
+	// stops renewing it.
+	taskLease struct {
+		redis  *redis.Client
+		key    string
+		holder string
+		ttl    time.Duration
+
+		// peer is the last seen holder of the lease, when not holder.
+		peer string
+	}
+)
+
+const (
+	// task lease acquisition results
+	leaseAcquired = "acquired"
+	leaseRenewed  = "renewed"
+	leaseHeld     = "held_by_peer"
+	leaseLost     = "lost"
+	leaseError    = "error"
+)
+
+var (
+	// acquireLeaseScript sets the KEYS[1] lease to the ARGV[1] holder with
+	// a ARGV[2] ms expiry, if the lease is not held or already held by
+	// ARGV[1]. It returns the lease holder before the call, or false if the
+	// lease was not held.
+	acquireLeaseScript = redis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if not holder or holder == ARGV[1] then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+end
+return holder
+`)
+
+	// renewLeaseScript resets the KEYS[1] lease expiry to ARGV[2] ms if it
+	// is held by ARGV[1]. It returns 1 if renewed, else 0.
+	renewLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+	// releaseLeaseScript deletes the KEYS[1] lease if it is held by ARGV[1].
+	// It returns 1 if released, else 0.
+	releaseLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+	taskLeaseCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "oc3",
+			Subsystem: "scheduler",
+			Name:      "task_lease_count",
+			Help:      "Task lease acquisition and renewal counter, by result: acquired, renewed, held_by_peer, lost or error.",
+		},
+		[]string{"desc", "result"},
+	)
+
+	taskLeaseFailoverCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "oc3",
+			Subsystem: "scheduler",
+			Name:      "task_lease_failover_count",
+			Help:      "Task lease acquisitions after the expiry of the lease of a peer scheduler.",
+		},
+		[]string{"desc"},
+	)
+
+	taskLeaseHolderGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "oc3",
+			Subsystem: "scheduler",
+			Name:      "task_lease_holder",
+			Help:      "1 if this scheduler holds the task lease, else 0.",
+		},
+		[]string{"desc"},
+	)
+)
+
+// newSchedulerID returns a scheduler id unique in the cluster of schedulers
+func newSchedulerID() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.New().String()[:8])
+}
+
+// newTaskLease returns the lease of the task for the scheduler holder. The
+// lease outlives the period and the maximum jitter of the next execution by
+// a quarter of the period.
+func newTaskLease(r *redis.Client, name, holder string, period, jitter time.Duration) *taskLease {
+	return &taskLease{
+		redis:  r,
+		key:    cachekeys.SchedulerLease + name,
+		holder: holder,
+		ttl:    period + period/4 + jitter,
+	}
+}
+
+// acquire acquires or renews the lease of task t, and returns true if
+// t can execute. The holder changes are logged and counted.
+func (l *taskLease) acquire(ctx context.Context, t *Task) bool {
+	prev, err := acquireLeaseScript.Run(ctx, l.redis, []string{l.key}, l.holder, l.ttl.Milliseconds()).Text()
+	switch {
+	case errors.Is(err, redis.Nil):
+		prev = ""
+	case err != nil:
+		t.Errorf("acquire lease %s: %s", l.key, err)
+		l.count(t, leaseError, false)
+		return false
+	}
+	switch prev {
+	case l.holder:
+		l.peer = ""
+		l.count(t, leaseRenewed, true)
+		return true
+	case "":
+		if l.peer != "" {
+			t.Warnf("lease acquired by %s after the expiry of the %s lease", l.holder, l.peer)
+			taskLeaseFailoverCounter.With(prometheus.Labels{"desc": t.name}).Inc()
+		} else {
+			t.Infof("lease acquired by %s", l.holder)
+		}
+		l.peer = ""
+		l.count(t, leaseAcquired, true)
+		return true
+	default:
+		if l.peer != prev {
+			t.Infof("lease held by %s", prev)
+		}
+		l.peer = prev
+		l.count(t, leaseHeld, false)
+		return false
+	}
+}
+
+// hold acquires or renews the lease like acquire, without logging nor
+// counting, for the api trigger requests. It returns the holder if the
+// lease is held by a peer scheduler, else "".
+func (l *taskLease) hold(ctx context.Context) (string, error) {
+	prev, err := acquireLeaseScript.Run(ctx, l.redis, []string{l.key}, l.holder, l.ttl.Milliseconds()).Text()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("acquire lease %s: %w", l.key, err)
+	case prev == l.holder:
+		return "", nil
+	default:
+		return prev, nil
+	}
+}
+
+// keepalive renews the lease every third of its ttl until ctx is done.
+// The execution is not interrupted if the lease is lost, but the loss is
+// logged and counted.
+func (l *taskLease) keepalive(ctx context.Context, t *Task) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !l.renew(ctx, t) {
+				return
+			}
+		}
+	}
+}
+
+// renew resets the lease expiry to its ttl. It returns false if the lease
+// is lost, or on ctx done.
+func (l *taskLease) renew(ctx context.Context, t *Task) bool {
+	n, err := renewLeaseScript.Run(ctx, l.redis, []string{l.key}, l.holder, l.ttl.Milliseconds()).Int()
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return false
+	case err != nil:
+		t.Errorf("renew lease %s: %s", l.key, err)
+		l.count(t, leaseError, true)
+		return true
+	case n == 0:
+		t.Warnf("lease lost by %s during the execution", l.holder)
+		l.count(t, leaseLost, false)
+		return false
+	default:
+		return true
+	}
+}
+
+// release releases the lease if held, so a peer scheduler acquires it on
+// its next try instead of waiting for the lease expiry.
+func (l *taskLease) release(ctx context.Context, t *Task) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	if n, err := releaseLeaseScript.Run(ctx, l.redis, []string{l.key}, l.holder).Int(); err != nil {
+		t.Errorf("release lease %s: %s", l.key, err)
+	} else if n == 1 {
+		t.Infof("lease released by %s", l.holder)
+		taskLeaseHolderGauge.With(prometheus.Labels{"desc": t.name}).Set(0)
+	}
+}
+
+func (l *taskLease) count(t *Task, result string, holder bool) {
+	taskLeaseCounter.With(prometheus.Labels{"desc": t.name, "result": result}).Inc()
+	if holder {
+		taskLeaseHolderGauge.With(prometheus.Labels{"desc": t.name}).Set(1)
+	} else {
+		taskLeaseHolderGauge.With(prometheus.Labels{"desc": t.name}).Set(0)
+	}
+}
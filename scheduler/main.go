@@ -5,9 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
-	"os"
-	"os/signal"
-	"syscall"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -19,9 +17,28 @@ type (
 		Redis *redis.Client
 		Ev    eventPublisher
 
-		states  map[string]State
-		cancels map[string]func()
-		sigC    chan os.Signal
+		// DrainTimeout is the maximum delay to wait for the running tasks
+		// to finish on shutdown, before canceling them.
+		DrainTimeout time.Duration
+
+		// ID is the scheduler id, unique in the cluster of schedulers. It
+		// values the task leases held by the scheduler. A random id is used
+		// when empty.
+		ID string
+
+		// Lease enables the per task redis leases, so the concurrent
+		// schedulers execute each task on only one of them.
+		Lease bool
+
+		states    map[string]State
+		cancels   map[string]func()
+		schedules map[string]taskSchedule
+		runtimes  taskRuntimes
+
+		// execCtx is the parent context of the task executions. It is
+		// canceled after the drain timeout on shutdown.
+		execCtx context.Context
+		running sync.WaitGroup
 	}
 
 	eventPublisher interface {
@@ -45,6 +62,8 @@ func (t *Scheduler) Debugf(format string, args ...any) {
 	slog.Debug(fmt.Sprintf("scheduler: "+format, args...))
 }
 
+// toggleTasks starts the enabled tasks and stops the disabled tasks. The
+// started tasks stop scheduling executions when ctx is done.
 func (t *Scheduler) toggleTasks(ctx context.Context, states map[string]State) {
 	for _, task := range Tasks {
 		if task.period == 0 {
@@ -66,23 +85,27 @@ func (t *Scheduler) toggleTasks(ctx context.Context, states map[string]State) {
 			cancel()
 			delete(t.cancels, name)
 		case !storedState.IsDisabled && !hasCancel:
-			ctx2, cancel := context.WithCancel(ctx)
+			ctx2, cancel := context.WithCancel(t.execCtx)
 			t.cancels[name] = cancel
 			task.SetDB(t.DB)
 			task.SetRedis(t.Redis)
 			task.SetEv(t.Ev)
+			task.schedule = t.schedules[name]
+			task.runtimes = t.runtimes
+			if t.Lease {
+				task.lease = newTaskLease(t.Redis, name, t.ID, task.schedule.interval(task.period, time.Now()), task.schedule.jitter)
+			}
+			t.running.Add(1)
 			go func() {
-				task.Start(ctx2)
+				defer t.running.Done()
+				task.Start(ctx2, ctx.Done())
 			}()
 		}
 	}
 	t.states = states
 }
 
-func (t *Scheduler) monitor() error {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
+func (t *Scheduler) monitor(ctx context.Context) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
@@ -102,11 +125,9 @@ func (t *Scheduler) monitor() error {
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			return
 		case <-ticker.C:
 			do()
-		case <-t.sigC:
-			cancel()
 		}
 	}
 }
@@ -129,27 +150,75 @@ func (t *Scheduler) GetStateMap(ctx context.Context) (map[string]State, error) {
 	for result.Next() {
 		var state State
 		var name string
-		err := result.Scan(&name, &state.LastRunAt, &state.IsDisabled)
+		var lastRunAt sql.NullTime
+		err := result.Scan(&name, &lastRunAt, &state.IsDisabled)
 		if err != nil {
 			return states, fmt.Errorf("scan: %w", err)
 		}
+		state.LastRunAt = lastRunAt.Time
 		states[name] = state
 	}
 	return states, nil
 }
 
-func (t *Scheduler) Run() error {
+// Run schedules the enabled tasks until ctx is done. Then it waits for the
+// running task executions to finish, and cancels those still running after
+// t.DrainTimeout.
+func (t *Scheduler) Run(ctx context.Context) error {
+	if t.ID == "" {
+		t.ID = newSchedulerID()
+	}
+	if t.Lease {
+		t.Infof("starting scheduler %s with task leases", t.ID)
+	} else {
+		t.Infof("starting scheduler %s", t.ID)
+	}
+	if err := Tasks.Validate(); err != nil {
+		return err
+	}
 	t.states = make(map[string]State)
 	t.cancels = make(map[string]func())
-	t.sigC = make(chan os.Signal, 1)
-
-	signal.Notify(t.sigC, os.Interrupt, syscall.SIGTERM)
-
-	t.monitor()
+	t.schedules = make(map[string]taskSchedule)
+	t.initRuntimes()
+	for _, task := range Tasks {
+		schedule, err := loadTaskSchedule(task.Name())
+		if err != nil {
+			return err
+		}
+		t.schedules[task.Name()] = schedule
+	}
 
+	execCtx, cancelExec := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelExec()
+	t.execCtx = execCtx
+
+	t.monitor(ctx)
+
+	t.Infof("stopped scheduling, drain the running tasks with timeout %s", t.DrainTimeout)
+	drained := make(chan struct{})
+	go func() {
+		t.running.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		t.Infof("drained")
+	case <-time.After(t.DrainTimeout):
+		t.Warnf("drain timeout, cancel the running tasks")
+		cancelExec()
+		<-drained
+	}
 	return nil
 }
 
+// initRuntimes allocates the tasks runtime, on Run or on api handlers
+// registration, whichever comes first.
+func (t *Scheduler) initRuntimes() {
+	if t.runtimes == nil {
+		t.runtimes = newTaskRuntimes(Tasks)
+	}
+}
+
 func NewTask(name string, db *sql.DB, r *redis.Client, ev eventPublisher) Task {
 	task := Tasks.Get(name)
 	task.SetEv(ev)
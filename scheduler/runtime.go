@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// taskRuntime is the local execution state of a task, reported by the
+	// scheduler api.
+	taskRuntime struct {
+		sync.Mutex
+
+		started      bool
+		running      bool
+		lastRunAt    time.Time
+		lastDuration time.Duration
+		lastErr      error
+
+		// cancel interrupts the running execution.
+		cancel context.CancelFunc
+
+		// trigger requests an immediate execution to the task Start loop.
+		trigger chan struct{}
+
+		// lease is the lease of the started task, nil if the scheduler
+		// runs without leases.
+		lease *taskLease
+	}
+
+	// taskRuntimes maps the task names, "<parent>: <child>" for the
+	// children, to their runtime. The map is not modified once the
+	// scheduler runs.
+	taskRuntimes map[string]*taskRuntime
+)
+
+func newTaskRuntimes(tasks TaskList) taskRuntimes {
+	m := make(taskRuntimes)
+	for _, task := range tasks {
+		m[task.name] = &taskRuntime{trigger: make(chan struct{}, 1)}
+		for _, child := range task.children {
+			m[childName(task.name, child.name)] = &taskRuntime{}
+		}
+	}
+	return m
+}
+
+func childName(parent, child string) string {
+	return parent + ": " + child
+}
+
+// begin marks the execution started with cancel as its interrupter.
+func (t *taskRuntime) begin(cancel context.CancelFunc) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.running = true
+	t.cancel = cancel
+	t.lastRunAt = time.Now()
+}
+
+// end records the execution result.
+func (t *taskRuntime) end(duration time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.running = false
+	t.cancel = nil
+	t.lastDuration = duration
+	t.lastErr = err
+}
+
+func (t *taskRuntime) setStarted(v bool, lease *taskLease) {
+	t.Lock()
+	defer t.Unlock()
+	t.started = v
+	t.lease = lease
+}
+
+// fill sets the last execution duration and error of info. The caller holds
+// the lock.
+func (t *taskRuntime) fill(info *TaskInfo) {
+	if t.lastDuration > 0 {
+		info.LastDuration = t.lastDuration.String()
+	}
+	if t.lastErr != nil {
+		info.LastError = t.lastErr.Error()
+	}
+}
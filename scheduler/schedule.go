@@ -0,0 +1,236 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type (
+	// taskSchedule plans the task executions. Without cron and window, the
+	// task executes every period, like before the schedules.
+	taskSchedule struct {
+		// cron, when set, replaces the period.
+		cron *cronSpec
+
+		// window, when set, restricts the executions to the daily
+		// maintenance window, and the task executes at most once per
+		// window occurrence.
+		window *dailyWindow
+
+		// jitter is the maximum random delay added to each planned
+		// execution, so the heavy tasks of the schedulers don't all start
+		// at the same time. The delay is capped to the window end.
+		jitter time.Duration
+	}
+
+	// dailyWindow is a daily time range in the scheduler local time. The
+	// range wraps midnight when end is before begin.
+	dailyWindow struct {
+		expr  string
+		begin time.Duration
+		end   time.Duration
+	}
+)
+
+// loadTaskSchedule returns the schedule of the task name configured by the
+// scheduler.task.<name>.schedule, .window and .jitter keys.
+func loadTaskSchedule(name string) (taskSchedule, error) {
+	var s taskSchedule
+	prefix := "scheduler.task." + name
+	if expr := viper.GetString(prefix + ".schedule"); expr != "" {
+		c, err := parseCron(expr)
+		if err != nil {
+			return s, fmt.Errorf("%s schedule: %w", name, err)
+		}
+		s.cron = c
+	}
+	if expr := viper.GetString(prefix + ".window"); expr != "" {
+		w, err := parseDailyWindow(expr)
+		if err != nil {
+			return s, fmt.Errorf("%s window: %w", name, err)
+		}
+		s.window = w
+	}
+	s.jitter = viper.GetDuration(prefix + ".jitter")
+	if s.jitter < 0 {
+		return s, fmt.Errorf("%s jitter: must be positive", name)
+	}
+	return s, nil
+}
+
+// parseDailyWindow parses a "HH:MM-HH:MM" daily window.
+func parseDailyWindow(expr string) (*dailyWindow, error) {
+	a, b, ok := strings.Cut(expr, "-")
+	if !ok {
+		return nil, fmt.Errorf("%q: expected HH:MM-HH:MM", expr)
+	}
+	parse := func(s string) (time.Duration, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(s))
+		if err != nil {
+			return 0, fmt.Errorf("%q: expected HH:MM-HH:MM", expr)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	w := &dailyWindow{expr: expr}
+	var err error
+	if w.begin, err = parse(a); err != nil {
+		return nil, err
+	}
+	if w.end, err = parse(b); err != nil {
+		return nil, err
+	}
+	if w.begin == w.end {
+		return nil, fmt.Errorf("%q: empty window", expr)
+	}
+	return w, nil
+}
+
+// occurrence returns the bounds of the window occurrence containing t, or
+// ok false if t is out of the window.
+func (w *dailyWindow) occurrence(t time.Time) (begin, end time.Time, ok bool) {
+	// the occurrence began today, or yesterday if it wraps midnight
+	for _, day := range []int{0, -1} {
+		begin = wallClock(t, day, w.begin)
+		end = w.endOf(begin)
+		if !t.Before(begin) && t.Before(end) {
+			return begin, end, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// nextBegin returns the begin of the first window occurrence after t.
+func (w *dailyWindow) nextBegin(t time.Time) time.Time {
+	begin := wallClock(t, 0, w.begin)
+	if !begin.After(t) {
+		begin = wallClock(t, 1, w.begin)
+	}
+	return begin
+}
+
+// endOf returns the end of the window occurrence beginning at begin. The
+// bounds are wall clock times, so a DST change shortens or extends the
+// occurrence.
+func (w *dailyWindow) endOf(begin time.Time) time.Time {
+	if w.end > w.begin {
+		return wallClock(begin, 0, w.end)
+	}
+	return wallClock(begin, 1, w.end)
+}
+
+// fit returns the first time not before t in a window occurrence not
+// containing last, so the task executes at most once per occurrence, and
+// the bounds of this occurrence.
+func (w *dailyWindow) fit(t, last time.Time) (time.Time, time.Time, time.Time) {
+	for {
+		begin, end, ok := w.occurrence(t)
+		switch {
+		case !ok:
+			t = w.nextBegin(t)
+		case !last.IsZero() && !last.Before(begin) && last.Before(end):
+			t = w.nextBegin(end.Add(-time.Nanosecond))
+		default:
+			return t, begin, end
+		}
+	}
+}
+
+// wallClock returns the time of the day t plus days, at the offset wall
+// clock time since midnight, in the t location.
+func wallClock(t time.Time, days int, offset time.Duration) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+days, int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, t.Location())
+}
+
+func (w *dailyWindow) String() string {
+	return w.expr
+}
+
+// next returns the planned time of the next execution of a task of period,
+// whose previous execution was planned at last, or never if last is zero,
+// and this planned time delayed by the jitter. The caller passes the
+// returned planned time as the next last, so the jitter never accumulates.
+// Without cron, the executions in a window are aligned to the window begin.
+// A slot missed while no scheduler was running is executed now, once.
+func (s taskSchedule) next(period time.Duration, last, now time.Time) (planned, at time.Time) {
+	var t time.Time
+	switch {
+	case s.cron != nil:
+		from := last
+		if from.IsZero() {
+			from = now
+		}
+		t = s.cron.next(from)
+		if t.IsZero() {
+			// never matches, retry later
+			t = now.Add(24 * time.Hour)
+			return t, t
+		}
+	case last.IsZero():
+		t = now
+	default:
+		t = last.Add(period)
+	}
+	if t.Before(now) {
+		t = now
+	}
+	var end time.Time
+	if s.window != nil {
+		var begin time.Time
+		t, begin, end = s.window.fit(t, last)
+		if s.cron == nil {
+			t = begin
+			if t.Before(now) {
+				t = now
+			}
+		}
+	}
+	planned, at = t, t
+	if s.jitter > 0 {
+		jitter := s.jitter
+		if !end.IsZero() && end.Sub(t) < jitter {
+			jitter = end.Sub(t)
+		}
+		if jitter > 0 {
+			at = t.Add(rand.N(jitter))
+		}
+	}
+	return planned, at
+}
+
+// interval returns the typical delay between two executions, used as the
+// lease duration base.
+func (s taskSchedule) interval(period time.Duration, now time.Time) time.Duration {
+	d := period
+	if s.cron != nil {
+		if a := s.cron.next(now); !a.IsZero() {
+			if b := s.cron.next(a); !b.IsZero() {
+				d = b.Sub(a)
+			}
+		}
+	}
+	if s.window != nil && d < 24*time.Hour {
+		d = 24 * time.Hour
+	}
+	return d
+}
+
+// describe returns the schedule description for the logs.
+func (s taskSchedule) describe(period time.Duration) string {
+	var l []string
+	if s.cron != nil {
+		l = append(l, "schedule="+s.cron.String())
+	} else {
+		l = append(l, "period="+period.String())
+	}
+	if s.window != nil {
+		l = append(l, "window="+s.window.String())
+	}
+	if s.jitter > 0 {
+		l = append(l, "jitter="+s.jitter.String())
+	}
+	return strings.Join(l, " ")
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func mustWindow(t *testing.T, expr string) *dailyWindow {
+	t.Helper()
+	w, err := parseDailyWindow(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestDailyWindowFit(t *testing.T) {
+	day := func(d, h, m int) time.Time {
+		return time.Date(2026, 1, d, h, m, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		name    string
+		window  string
+		t, last time.Time
+		want    time.Time
+		wantEnd time.Time
+	}{
+		{"before", "01:00-05:00", day(2, 0, 30), time.Time{}, day(2, 1, 0), day(2, 5, 0)},
+		{"in", "01:00-05:00", day(2, 2, 0), time.Time{}, day(2, 2, 0), day(2, 5, 0)},
+		{"after", "01:00-05:00", day(2, 6, 0), time.Time{}, day(3, 1, 0), day(3, 5, 0)},
+		{"end is excluded", "01:00-05:00", day(2, 5, 0), time.Time{}, day(3, 1, 0), day(3, 5, 0)},
+		{"done in the occurrence", "01:00-05:00", day(2, 2, 0), day(2, 1, 10), day(3, 1, 0), day(3, 5, 0)},
+		{"done in the previous occurrence", "01:00-05:00", day(2, 2, 0), day(1, 4, 59), day(2, 2, 0), day(2, 5, 0)},
+		{"wrap before midnight", "22:00-02:00", day(2, 23, 0), time.Time{}, day(2, 23, 0), day(3, 2, 0)},
+		{"wrap after midnight", "22:00-02:00", day(3, 1, 0), time.Time{}, day(3, 1, 0), day(3, 2, 0)},
+		{"wrap out", "22:00-02:00", day(2, 12, 0), time.Time{}, day(2, 22, 0), day(3, 2, 0)},
+		{"wrap done before midnight", "22:00-02:00", day(3, 1, 0), day(2, 23, 0), day(3, 22, 0), day(4, 2, 0)},
+		{"wrap done in the previous occurrence", "22:00-02:00", day(2, 23, 0), day(2, 1, 0), day(2, 23, 0), day(3, 2, 0)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _, end := mustWindow(t, tc.window).fit(tc.t, tc.last)
+			if !got.Equal(tc.want) || !end.Equal(tc.wantEnd) {
+				t.Errorf("fit(%s, %s): got %s-%s, expected %s-%s", tc.t, tc.last, got, end, tc.want, tc.wantEnd)
+			}
+		})
+	}
+}
+
+func TestDailyWindowFitDST(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := mustWindow(t, "01:00-05:00")
+
+	// the spring forward occurrence is 3 hours long, with wall clock bounds
+	got, begin, end := w.fit(time.Date(2026, 3, 29, 0, 0, 0, 0, paris), time.Time{})
+	if want := time.Date(2026, 3, 29, 1, 0, 0, 0, paris); !got.Equal(want) || !begin.Equal(want) {
+		t.Errorf("spring forward begin: got %s, expected %s", got, want)
+	}
+	if want := time.Date(2026, 3, 29, 5, 0, 0, 0, paris); !end.Equal(want) || end.Sub(begin) != 3*time.Hour {
+		t.Errorf("spring forward end: got %s, expected %s", end, want)
+	}
+
+	// the fall back occurrence is 5 hours long
+	_, begin, end = w.fit(time.Date(2026, 10, 25, 0, 0, 0, 0, paris), time.Time{})
+	if end.Sub(begin) != 5*time.Hour || end.In(paris).Hour() != 5 {
+		t.Errorf("fall back occurrence: got %s-%s", begin, end)
+	}
+}
+
+func TestScheduleNextWindowJitter(t *testing.T) {
+	// the scrub_1d schedule
+	s := taskSchedule{
+		window: mustWindow(t, "01:00-05:00"),
+		jitter: 30 * time.Minute,
+	}
+	period := 24 * time.Hour
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	planned, at := s.next(period, time.Time{}, now)
+	for i := 0; i < 365; i++ {
+		want := time.Date(2026, 1, 1+i, 1, 0, 0, 0, time.UTC)
+		if !planned.Equal(want) {
+			t.Fatalf("run %d: planned %s, expected %s", i, planned, want)
+		}
+		if at.Before(planned) || !at.Before(planned.Add(s.jitter)) {
+			t.Fatalf("run %d: at %s, expected in [%s, %s)", i, at, planned, planned.Add(s.jitter))
+		}
+		// the execution ends 10 minutes after its jittered begin
+		planned, at = s.next(period, planned, at.Add(10*time.Minute))
+	}
+}
+
+func TestScheduleNextPeriodJitter(t *testing.T) {
+	s := taskSchedule{jitter: 10 * time.Minute}
+	period := time.Hour
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	planned, at := s.next(period, time.Time{}, now)
+	for i := 0; i < 1000; i++ {
+		if want := now.Add(time.Duration(i) * period); !planned.Equal(want) {
+			t.Fatalf("run %d: planned %s, expected %s", i, planned, want)
+		}
+		if at.Before(planned) || !at.Before(planned.Add(s.jitter)) {
+			t.Fatalf("run %d: at %s, expected in [%s, %s)", i, at, planned, planned.Add(s.jitter))
+		}
+		planned, at = s.next(period, planned, at.Add(time.Minute))
+	}
+}
+
+func TestScheduleNextCronJitterCappedToWindowEnd(t *testing.T) {
+	c, err := parseCron("50 4 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := taskSchedule{
+		cron:   c,
+		window: mustWindow(t, "01:00-05:00"),
+		jitter: 30 * time.Minute,
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	planned, at := s.next(time.Hour, time.Time{}, now)
+	if want := time.Date(2026, 1, 1, 4, 50, 0, 0, time.UTC); !planned.Equal(want) {
+		t.Fatalf("planned %s, expected %s", planned, want)
+	}
+	if end := time.Date(2026, 1, 1, 5, 0, 0, 0, time.UTC); at.Before(planned) || !at.Before(end) {
+		t.Fatalf("at %s, expected in [%s, %s)", at, planned, end)
+	}
+}
+
+func TestScheduleNextMissed(t *testing.T) {
+	s := taskSchedule{window: mustWindow(t, "01:00-05:00")}
+	last := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	// the missed occurrences are executed once, now
+	now := time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC)
+	if planned, _ := s.next(24*time.Hour, last, now); !planned.Equal(now) {
+		t.Errorf("in window: planned %s, expected %s", planned, now)
+	}
+
+	// or at the next window begin
+	now = time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	if planned, _ := s.next(24*time.Hour, last, now); !planned.Equal(time.Date(2026, 1, 6, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("out of window: planned %s", planned)
+	}
+}
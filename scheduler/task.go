@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -28,10 +29,41 @@ type (
 		fn       TaskFunc
 		children TaskList
 
+		// dependsOn is the names of the sibling children executed, and
+		// successful, before this child.
+		dependsOn []string
+
+		// parallel is the maximum number of children executed at the
+		// same time, 1 if zero. The scheduler.task.<name>.parallel key
+		// overrides it.
+		parallel int
+
 		db      *sql.DB
 		Redis   *redis.Client
 		ev      eventPublisher
 		session *cdb.Session
+
+		// lease, when set, is acquired before each scheduled execution, so
+		// the task executes on one of the concurrent schedulers.
+		lease *taskLease
+
+		// schedule plans the executions, every period by default.
+		schedule taskSchedule
+
+		// runtimes records the executions state, for the scheduler api.
+		runtimes taskRuntimes
+
+		// rows counts the rows affected by the running execution, reported
+		// by the task function with AddRowsAffected.
+		rows *execRows
+	}
+
+	// execRows is the number of rows affected by an execution, known only
+	// if reported.
+	execRows struct {
+		sync.Mutex
+		known bool
+		n     int64
 	}
 
 	TaskList []Task
@@ -49,8 +81,6 @@ const (
 
 var (
 	Tasks = TaskList{
-		TaskChecks,
-		TaskSysreport,
 		TaskRefreshBActionErrors,
 		TaskAlertUpdateActionErrors,
 		TaskUpdateVirtualAssets,
@@ -92,12 +122,15 @@ func (t TaskList) Print() {
 	t.Fprint(os.Stdout)
 }
 
+// Fprint writes the tasks, and their children dependency graph.
 func (t TaskList) Fprint(w io.Writer) {
 	for _, task := range t {
-		fmt.Fprintln(w, task.name)
-		for _, child := range task.children {
-			fmt.Fprintln(w, "  "+child.name)
+		if len(task.children) > 1 {
+			fmt.Fprintf(w, "%s (parallel %d)\n", task.name, task.parallelism())
+		} else {
+			fmt.Fprintln(w, task.name)
 		}
+		task.fprintChildren(w)
 	}
 }
 
@@ -191,49 +224,100 @@ func (t *Task) Debugf(format string, args ...any) {
 	slog.Debug(fmt.Sprintf(t.name+": "+format, args...))
 }
 
-func (t *Task) Start(ctx context.Context) {
+// Start executes the task every period, and on trigger requests, until ctx
+// is done or stop is closed. A running execution is interrupted by ctx, but
+// not by stop.
+func (t *Task) Start(ctx context.Context, stop <-chan struct{}) {
 	state, err := t.GetState(ctx)
 	if err != nil {
 		t.Errorf("%s", err)
 	}
-	var initialDelay time.Duration
-	if !state.LastRunAt.IsZero() {
-		initialDelay = state.LastRunAt.Add(t.period).Sub(time.Now())
-	} else {
-		initialDelay = 0
-	}
-
+	now := time.Now()
+	planned, at := t.schedule.next(t.period, state.LastRunAt, now)
+	initialDelay := at.Sub(now)
 	if initialDelay < 0 {
 		initialDelay = 0
 	}
 
-	t.Infof("start with period=%s, last was %s, next in %s", t.period, state.LastRunAt.Format(time.RFC3339), initialDelay)
+	t.Infof("start with %s, last was %s, next in %s", t.schedule.describe(t.period), state.LastRunAt.Format(time.RFC3339), initialDelay)
 	timer := time.NewTimer(initialDelay)
 	defer timer.Stop()
+	if t.lease != nil {
+		defer t.lease.release(ctx, t)
+	}
+
+	var trigger <-chan struct{}
+	if rt := t.runtimes[t.name]; rt != nil {
+		trigger = rt.trigger
+		rt.setStarted(true, t.lease)
+		defer rt.setStarted(false, nil)
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-stop:
+			return
 		case <-timer.C:
-			// Update the last run time persistant store
-			if err := t.SetLastRunAt(ctx); err != nil {
-				t.Errorf("%s", err)
-			}
-
-			// Blocking fn execution, no more timer event until terminated.
-			beginAt := time.Now()
-			_ = t.Exec(ctx)
-			endAt := time.Now()
+			planned = t.run(ctx, timer, planned, false)
+		case <-trigger:
+			t.Infof("triggered")
+			planned = t.run(ctx, timer, planned, true)
+		}
+	}
+}
 
-			// Plan the next execution, correct the drift
-			nextPeriod := beginAt.Add(t.period).Sub(endAt)
-			if nextPeriod < 0 {
-				nextPeriod = time.Second
-			}
-			timer.Reset(nextPeriod)
+// run executes the task if the lease is acquired, and plans the next
+// execution. planned is the un-jittered time of the current execution, or
+// of the pending execution when triggered. It returns the planned time of
+// the next execution.
+func (t *Task) run(ctx context.Context, timer *time.Timer, planned time.Time, triggered bool) time.Time {
+	beginAt := time.Now()
+	if t.lease != nil && !t.lease.acquire(ctx, t) {
+		if triggered {
+			// keep the planned execution
+			return planned
 		}
+		// A peer scheduler executes the task. Try again next
+		// time, in case the peer stops renewing its lease.
+		next, at := t.schedule.next(t.period, planned, beginAt)
+		timer.Reset(at.Sub(beginAt))
+		return next
+	}
+
+	// Update the last run time persistant store
+	if err := t.SetLastRunAt(ctx); err != nil {
+		t.Errorf("%s", err)
+	}
+
+	// Blocking fn execution, no more timer event until terminated.
+	if t.lease != nil {
+		keepaliveCtx, stopKeepalive := context.WithCancel(ctx)
+		go t.lease.keepalive(keepaliveCtx, t)
+		_ = t.Exec(ctx)
+		stopKeepalive()
+		// the lease expiry now covers the delay to the next execution
+		t.lease.renew(ctx, t)
+	} else {
+		_ = t.Exec(ctx)
+	}
+	endAt := time.Now()
+
+	// Plan the next execution from the planned time, not from the
+	// jittered begin, so the jitter doesn't accumulate. A triggered
+	// execution replans from its begin.
+	last := planned
+	if triggered {
+		last = beginAt
 	}
+	next, at := t.schedule.next(t.period, last, endAt)
+	nextPeriod := at.Sub(endAt)
+	if nextPeriod <= 0 {
+		nextPeriod = time.Second
+	}
+	timer.Reset(nextPeriod)
+	return next
 }
 
 func (t *Task) Exec(ctx context.Context) (err error) {
@@ -241,34 +325,88 @@ func (t *Task) Exec(ctx context.Context) (err error) {
 	status := taskExecStatusOk
 	begin := time.Now()
 
-	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	// The timeout applies to the task function. The children executions
+	// have their own timeout, so a slow child does not consume the budget
+	// of the others.
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// nil when executed out of a scheduler
+	rt := t.runtimes[t.name]
+	rt.begin(cancel)
+
+	t.rows = &execRows{}
+
 	// Execution
 	if t.fn != nil {
-		err = t.fn(ctx, t)
+		fnCtx, fnCancel := context.WithTimeout(ctx, t.timeout)
+		err = t.fn(fnCtx, t)
+		fnCancel()
 	}
 
-	for _, child := range t.children {
-		child.db = t.db
-		child.ev = t.ev
-		child.session = t.session
-		child.name = fmt.Sprintf("%s: %s", t.name, child.name)
-		err = errors.Join(err, child.Exec(ctx))
+	if len(t.children) > 0 {
+		err = errors.Join(err, t.execChildren(ctx))
 	}
 
-	duration := time.Since(begin)
+	end := time.Now()
+	duration := end.Sub(begin)
 	if err != nil {
 		status = taskExecStatusFailed
 		t.Errorf("%s [%s]", err, duration)
 	} else {
 		t.Infof("%s [%s]", status, duration)
 	}
+	rt.end(duration, err)
 	taskExecCounter.With(prometheus.Labels{"desc": t.name, "status": status}).Inc()
 	taskExecDuration.With(prometheus.Labels{"desc": t.name, "status": status}).Observe(duration.Seconds())
+	t.saveRun(ctx, begin, end, status, err)
 	return
 }
 
+// AddRowsAffected adds n to the number of rows affected by the running
+// execution, saved in the runs history.
+func (t *Task) AddRowsAffected(n int64) {
+	if t.rows == nil {
+		return
+	}
+	t.rows.Lock()
+	defer t.rows.Unlock()
+	t.rows.known = true
+	t.rows.n += n
+}
+
+func (t *execRows) get() (int64, bool) {
+	if t == nil {
+		return 0, false
+	}
+	t.Lock()
+	defer t.Unlock()
+	return t.n, t.known
+}
+
+// saveRun saves the execution to the runs history. The history is saved
+// even if the execution timed out or was canceled.
+func (t *Task) saveRun(ctx context.Context, begin, end time.Time, status string, err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+
+	run := cdb.SchedulerRun{
+		TaskName: t.name,
+		Begin:    begin,
+		End:      end,
+		Status:   status,
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+	if n, ok := t.rows.get(); ok {
+		run.RowsAffected = &n
+	}
+	if err := cdb.New(t.db).InsertSchedulerRun(ctx, run); err != nil {
+		t.Errorf("save run: %s", err)
+	}
+}
+
 func (t *Task) GetState(ctx context.Context) (State, error) {
 	var state State
 
@@ -277,10 +415,13 @@ func (t *Task) GetState(ctx context.Context) (State, error) {
 
 	query := "SELECT last_run_at,is_disabled FROM oc3_scheduler WHERE task_name = ? ORDER BY id DESC LIMIT 1"
 
-	err := t.db.QueryRowContext(ctx, query, t.name).Scan(&state.LastRunAt, &state.IsDisabled)
+	// last_run_at is null for the tasks disabled before their first run
+	var lastRunAt sql.NullTime
+	err := t.db.QueryRowContext(ctx, query, t.name).Scan(&lastRunAt, &state.IsDisabled)
 	if err == sql.ErrNoRows {
 		return state, nil
 	}
+	state.LastRunAt = lastRunAt.Time
 	return state, err
 }
 
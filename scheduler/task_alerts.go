@@ -7,12 +7,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/spf13/viper"
+
 	"github.com/opensvc/oc3/cdb"
 )
 
 var TaskAlert1M = Task{
 	name: "alerts_1m",
 	children: TaskList{
+		TaskAlertHeartbeatsNotBeating,
 		TaskAlertInstancesNotUpdated,
 	},
 	period:  time.Minute,
@@ -174,12 +177,34 @@ var TaskAlertInstancesNotUpdated = Task{
 	timeout: 5 * time.Minute,
 }
 
+var TaskAlertHeartbeatsNotBeating = Task{
+	name:    "alert_heartbeats_not_beating",
+	fn:      taskAlertHeartbeatsNotBeating,
+	timeout: time.Minute,
+}
+
 var TaskAlertChecksNotUpdated = Task{
 	name:    "alert_checks_not_updated",
 	fn:      taskAlertChecksNotUpdated,
 	timeout: 5 * time.Minute,
 }
 
+func taskAlertHeartbeatsNotBeating(ctx context.Context, task *Task) error {
+	threshold := viper.GetDuration("scheduler.task.alert_heartbeats_not_beating.threshold")
+	odb, err := task.DBX(ctx)
+	if err != nil {
+		return err
+	}
+	defer odb.Rollback()
+	if err := odb.DashboardUpdateHeartbeatsNotBeating(ctx, threshold); err != nil {
+		return err
+	}
+	if err := odb.Session.NotifyChanges(ctx); err != nil {
+		return err
+	}
+	return odb.Commit()
+}
+
 func taskAlertMACDup(ctx context.Context, task *Task) error {
 	odb, err := task.DBX(ctx)
 	if err != nil {
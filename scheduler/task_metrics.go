@@ -5,12 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/go-graphite/go-whisper"
-	"github.com/spf13/viper"
 
 	"github.com/opensvc/oc3/cdb"
 	"github.com/opensvc/oc3/timeseries"
@@ -23,14 +21,6 @@ var TaskMetrics = Task{
 	timeout: 15 * time.Minute,
 }
 
-func MakeWSPFilename(format string, args ...any) (string, error) {
-	directory := viper.GetString("scheduler.directories.uploads")
-	if directory == "" {
-		return "", fmt.Errorf("define scheduler.directories.uploads")
-	}
-	return filepath.Join(directory, "stats", fmt.Sprintf(format+".wsp", args...)), nil
-}
-
 func taskMetrics(ctx context.Context, task *Task) error {
 	odb := task.DB()
 	metrics, err := odb.GetMetricsWithHistorize(ctx)
@@ -82,7 +72,7 @@ func taskMetrics(ctx context.Context, task *Task) error {
 				if !instance.Valid || instance.String == "" {
 					instance.String = "None"
 				}
-				wspFilename, err = MakeWSPFilename("/metrics/%d/fsets/%d/%s", metric.ID, fsetID, instance.String)
+				wspFilename, err = timeseries.MakeWSPFilename("/metrics/%d/fsets/%d/%s", metric.ID, fsetID, instance.String)
 				if err != nil {
 					return err
 				}
@@ -109,7 +99,7 @@ func taskMetrics(ctx context.Context, task *Task) error {
 				if !ok {
 					return fmt.Errorf("value %v is not a float64", values[i])
 				}
-				wspFilename, err := MakeWSPFilename("/metrics/%d/fsets/%d/%s", metric.ID, fsetID, instance)
+				wspFilename, err := timeseries.MakeWSPFilename("/metrics/%d/fsets/%d/%s", metric.ID, fsetID, instance)
 				if err != nil {
 					return err
 				}
@@ -135,16 +135,23 @@ var TaskScrubCompRulesetsServices = Task{
 	timeout: time.Minute,
 }
 
+// TaskScrubCompStatus purges the compliance status of the modulesets no
+// longer attached, so it executes after the attachments scrub.
 var TaskScrubCompStatus = Task{
 	name:    "scrub_comp_status",
 	fn:      taskScrubCompStatus,
 	timeout: time.Minute,
+	dependsOn: []string{
+		"scrub_comp_modulesets_nodes",
+		"scrub_comp_modulesets_services",
+	},
 }
 
 var TaskUpdateStorArrayDGQuota = Task{
-	name:    "scrub_update_stor_array_dg_quota",
-	fn:      taskUpdateStorArrayDGQuota,
-	timeout: time.Minute,
+	name:      "scrub_update_stor_array_dg_quota",
+	fn:        taskUpdateStorArrayDGQuota,
+	timeout:   time.Minute,
+	dependsOn: []string{"scrub_stor_array"},
 }
 
 var TaskScrub1D = Task{
@@ -168,7 +175,8 @@ var TaskScrub1D = Task{
 		TaskScrubSvcdisks,
 		TaskUpdateStorArrayDGQuota,
 	},
-	timeout: 5 * time.Minute,
+	parallel: 4,
+	timeout:  5 * time.Minute,
 }
 
 var TaskScrub1H = Task{
@@ -251,9 +259,12 @@ func taskScrubResources(ctx context.Context, task *Task) error {
 	}
 
 	// Update the `resmon` table
-	if modified, err := odb.ResourceUpdateStatus(ctx, resources, "undef"); err != nil {
+	modified, err := odb.ResourceUpdateStatus(ctx, resources, "undef")
+	if err != nil {
 		return err
-	} else if int(modified) != n {
+	}
+	task.AddRowsAffected(modified)
+	if int(modified) != n {
 		task.Infof("set %d/%d resmon status to undef (no live instance) amongst %s", modified, n, names)
 	} else {
 		task.Infof("set %d resmon status to undef (no live instance) for %s", n, names)
@@ -27,6 +27,7 @@ func deleteBatched(ctx context.Context, task *Task, table, dateCol, orderbyCol,
 		return err
 	}
 
+	task.AddRowsAffected(totalDeleted)
 	task.Infof("%s: deletion complete. retention: %d days. batch size: %d. total batches: %d. total rows deleted: %d", table, retention, batchSize, batchCount, totalDeleted)
 	return nil
 }
@@ -69,5 +70,6 @@ func taskTrimRun(ctx context.Context, task *Task) (err error) {
 	err = errors.Join(err, deleteBatched(ctx, task, "comp_run_ruleset", "date", "id", ""))
 	err = errors.Join(err, deleteBatched(ctx, task, "links", "link_last_consultation_date", "id", ""))
 	err = errors.Join(err, deleteBatched(ctx, task, "services_log", "svc_end", "id", ""))
+	err = errors.Join(err, deleteBatched(ctx, task, "oc3_scheduler_runs", "end", "id", ""))
 	return
 }
@@ -10,13 +10,18 @@
 //
 // # Regenerate schema/tables.go
 //
-//	go run ./schema/gen columns.txt schema/tables.go
+//	go run ./schema/gen columns.txt schema/columns_upgrade.txt schema/tables.go
+//
+// The columns of the next files are merged to the columns of the first one.
+// schema/columns_upgrade.txt lists the columns of schema/upgrade.sql, so they
+// are kept when the export is made from a database not yet upgraded. The
+// columns already exported are ignored.
 //
 // # Exclude tables
 //
 // Pass --exclude with a file listing tables to skip (one per line, # for comments):
 //
-//	go run ./schema/gen --exclude exclude.txt columns.txt schema/tables.go
+//	go run ./schema/gen --exclude exclude.txt columns.txt schema/columns_upgrade.txt schema/tables.go
 package main
 
 import (
@@ -24,6 +29,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -38,20 +44,20 @@ type column struct {
 func main() {
 	excludeFile := flag.String("exclude", "", "file listing tables to exclude (one per line, # for comments)")
 	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, "usage: schema/gen [--exclude exclude.txt] <columns.txt> <output.go>")
+		fmt.Fprintln(os.Stderr, "usage: schema/gen [--exclude exclude.txt] <columns.txt>... <output.go>")
 		flag.PrintDefaults()
 	}
 	flag.Parse()
 
-	if flag.NArg() != 2 {
+	if flag.NArg() < 2 {
 		flag.Usage()
 		os.Exit(1)
 	}
 
 	excluded := loadExcludeList(*excludeFile)
-	cols, tables := parse(flag.Arg(0), excluded)
+	cols, tables := parse(flag.Args()[:flag.NArg()-1], excluded)
 
-	f, err := os.Create(flag.Arg(1))
+	f, err := os.Create(flag.Arg(flag.NArg() - 1))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -88,48 +94,69 @@ func loadExcludeList(path string) map[string]bool {
 	return excluded
 }
 
-// parse reads columns.txt and returns columns + ordered table list (no views, no excluded tables).
-func parse(path string, excluded map[string]bool) ([]column, []string) {
-	f, err := os.Open(path)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-	defer f.Close()
-
+// parse reads the columns.txt files and returns columns + ordered table list (no views, no excluded tables).
+// The columns of a table already read from a previous file are ignored. The
+// tables are ordered by case insensitive name, like the export, and the
+// columns by table then file order.
+func parse(paths []string, excluded map[string]bool) ([]column, []string) {
 	var cols []column
 	seen := map[string]bool{}
+	seenCol := map[string]bool{}
 	var tables []string
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Split(line, "\t")
-		if len(parts) < 4 {
-			continue
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-		tbl := parts[0]
-		col := parts[1]
-		typ := parts[2]
-		nullable := parts[3] == "YES"
 
-		// skip header, views, and explicitly excluded tables
-		if tbl == "TABLE_NAME" || strings.HasPrefix(tbl, "v_") || excluded[tbl] {
-			continue
-		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			parts := strings.Split(line, "\t")
+			if len(parts) < 4 {
+				continue
+			}
+			tbl := parts[0]
+			col := parts[1]
+			typ := parts[2]
+			nullable := parts[3] == "YES"
 
-		if !seen[tbl] {
-			seen[tbl] = true
-			tables = append(tables, tbl)
+			// skip header, views, and explicitly excluded tables
+			if tbl == "TABLE_NAME" || strings.HasPrefix(tbl, "v_") || excluded[tbl] {
+				continue
+			}
+			if seenCol[tbl+"."+col] {
+				continue
+			}
+			seenCol[tbl+"."+col] = true
+
+			if !seen[tbl] {
+				seen[tbl] = true
+				tables = append(tables, tbl)
+			}
+			cols = append(cols, column{tbl, col, typ, nullable})
 		}
-		cols = append(cols, column{tbl, col, typ, nullable})
+		f.Close()
+	}
+
+	sort.SliceStable(tables, func(i, j int) bool {
+		return strings.ToUpper(tables[i]) < strings.ToUpper(tables[j])
+	})
+	order := make(map[string]int, len(tables))
+	for i, t := range tables {
+		order[t] = i
 	}
+	sort.SliceStable(cols, func(i, j int) bool {
+		return order[cols[i].table] < order[cols[j].table]
+	})
 	return cols, tables
 }
 
 func emit(w *bufio.Writer, cols []column, tables []string) {
 	fmt.Fprintln(w, "// Code generated by schema/gen. DO NOT EDIT.")
-	fmt.Fprintln(w, "// Regenerate with: go run ./schema/gen columns.txt schema/tables.go")
+	fmt.Fprintln(w, "// Regenerate with: go run ./schema/gen columns.txt schema/columns_upgrade.txt schema/tables.go")
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, "package schema")
 	fmt.Fprintln(w, "")
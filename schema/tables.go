@@ -1,5 +1,5 @@
 // Code generated by schema/gen. DO NOT EDIT.
-// Regenerate with: go run ./schema/gen columns.txt schema/tables.go
+// Regenerate with: go run ./schema/gen columns.txt schema/columns_upgrade.txt schema/tables.go
 
 package schema
 
@@ -55,6 +55,7 @@ var (
 	TDashboard                    = &Table{Name: "dashboard"}
 	TDashboardEvents              = &Table{Name: "dashboard_events"}
 	TDashboardRef                 = &Table{Name: "dashboard_ref"}
+	TDashboardSeverityRules       = &Table{Name: "dashboard_severity_rules"}
 	TDigit                        = &Table{Name: "digit"}
 	TDiskinfo                     = &Table{Name: "diskinfo"}
 	TDiskBlacklist                = &Table{Name: "disk_blacklist"}
@@ -104,6 +105,7 @@ var (
 	TNodeUsers                    = &Table{Name: "node_users"}
 	TObsolescence                 = &Table{Name: "obsolescence"}
 	TOc3Scheduler                 = &Table{Name: "oc3_scheduler"}
+	TOc3SchedulerRuns             = &Table{Name: "oc3_scheduler_runs"}
 	TPackages                     = &Table{Name: "packages"}
 	TPatches                      = &Table{Name: "patches"}
 	TPkgSigProvider               = &Table{Name: "pkg_sig_provider"}
@@ -159,6 +161,7 @@ var (
 	TSvcmonLogLast                = &Table{Name: "svcmon_log_last"}
 	TSvcTags                      = &Table{Name: "svc_tags"}
 	TSwitches                     = &Table{Name: "switches"}
+	TSysreportLog                 = &Table{Name: "sysreport_log"}
 	TSysrepAllow                  = &Table{Name: "sysrep_allow"}
 	TSysrepChanging               = &Table{Name: "sysrep_changing"}
 	TSysrepSecure                 = &Table{Name: "sysrep_secure"}
@@ -413,6 +416,7 @@ var (
 	ClustersClusterID   = &Col{T: TClusters, Name: "cluster_id", Nullable: true}
 	ClustersClusterName = &Col{T: TClusters, Name: "cluster_name", Nullable: false}
 	ClustersClusterData = &Col{T: TClusters, Name: "cluster_data", Nullable: true}
+	ClustersUpdated     = &Col{T: TClusters, Name: "updated", Nullable: false}
 )
 
 // Columns of comp_log
@@ -667,6 +671,18 @@ var (
 	DashboardRefDashDict = &Col{T: TDashboardRef, Name: "dash_dict", Nullable: true}
 )
 
+// Columns of dashboard_severity_rules
+var (
+	DashboardSeverityRulesID            = &Col{T: TDashboardSeverityRules, Name: "id", Nullable: false}
+	DashboardSeverityRulesPriority      = &Col{T: TDashboardSeverityRules, Name: "priority", Nullable: false}
+	DashboardSeverityRulesDashType      = &Col{T: TDashboardSeverityRules, Name: "dash_type", Nullable: false}
+	DashboardSeverityRulesApp           = &Col{T: TDashboardSeverityRules, Name: "app", Nullable: false}
+	DashboardSeverityRulesEnv           = &Col{T: TDashboardSeverityRules, Name: "env", Nullable: false}
+	DashboardSeverityRulesObjectPattern = &Col{T: TDashboardSeverityRules, Name: "object_pattern", Nullable: false}
+	DashboardSeverityRulesSeverity      = &Col{T: TDashboardSeverityRules, Name: "severity", Nullable: false}
+	DashboardSeverityRulesUpdated       = &Col{T: TDashboardSeverityRules, Name: "updated", Nullable: false}
+)
+
 // Columns of digit
 var (
 	DigitI = &Col{T: TDigit, Name: "i", Nullable: false}
@@ -1224,6 +1240,17 @@ var (
 	Oc3SchedulerLastRunAt  = &Col{T: TOc3Scheduler, Name: "last_run_at", Nullable: true}
 )
 
+// Columns of oc3_scheduler_runs
+var (
+	Oc3SchedulerRunsID           = &Col{T: TOc3SchedulerRuns, Name: "id", Nullable: false}
+	Oc3SchedulerRunsTaskName     = &Col{T: TOc3SchedulerRuns, Name: "task_name", Nullable: false}
+	Oc3SchedulerRunsBegin        = &Col{T: TOc3SchedulerRuns, Name: "begin", Nullable: false}
+	Oc3SchedulerRunsEnd          = &Col{T: TOc3SchedulerRuns, Name: "end", Nullable: false}
+	Oc3SchedulerRunsStatus       = &Col{T: TOc3SchedulerRuns, Name: "status", Nullable: false}
+	Oc3SchedulerRunsError        = &Col{T: TOc3SchedulerRuns, Name: "error", Nullable: true}
+	Oc3SchedulerRunsRowsAffected = &Col{T: TOc3SchedulerRuns, Name: "rows_affected", Nullable: true}
+)
+
 // Columns of packages
 var (
 	PackagesID             = &Col{T: TPackages, Name: "id", Nullable: false}
@@ -1834,6 +1861,7 @@ var (
 	SvcmonMonSmonGlobalExpect = &Col{T: TSvcmon, Name: "mon_smon_global_expect", Nullable: true}
 	SvcmonMonFrozenAt         = &Col{T: TSvcmon, Name: "mon_frozen_at", Nullable: true}
 	SvcmonMonEncapFrozenAt    = &Col{T: TSvcmon, Name: "mon_encap_frozen_at", Nullable: true}
+	SvcmonMonMergeRule        = &Col{T: TSvcmon, Name: "mon_merge_rule", Nullable: true}
 )
 
 // Columns of svcmon_log
@@ -1912,6 +1940,17 @@ var (
 	SwitchesSwIndex     = &Col{T: TSwitches, Name: "sw_index", Nullable: true}
 )
 
+// Columns of sysreport_log
+var (
+	SysreportLogID         = &Col{T: TSysreportLog, Name: "id", Nullable: false}
+	SysreportLogNodeID     = &Col{T: TSysreportLog, Name: "node_id", Nullable: false}
+	SysreportLogCommitID   = &Col{T: TSysreportLog, Name: "commit_id", Nullable: false}
+	SysreportLogCommitDate = &Col{T: TSysreportLog, Name: "commit_date", Nullable: false}
+	SysreportLogMessage    = &Col{T: TSysreportLog, Name: "message", Nullable: true}
+	SysreportLogChanges    = &Col{T: TSysreportLog, Name: "changes", Nullable: true}
+	SysreportLogUpdated    = &Col{T: TSysreportLog, Name: "updated", Nullable: false}
+)
+
 // Columns of sysrep_allow
 var (
 	SysrepAllowID      = &Col{T: TSysrepAllow, Name: "id", Nullable: false}
@@ -2155,6 +2194,7 @@ var AllCols = []*Col{
 	ClustersClusterID,
 	ClustersClusterName,
 	ClustersClusterData,
+	ClustersUpdated,
 	CompLogID,
 	CompLogRunModule,
 	CompLogRunStatus,
@@ -2299,6 +2339,14 @@ var AllCols = []*Col{
 	DashboardRefDashType,
 	DashboardRefDashFmt,
 	DashboardRefDashDict,
+	DashboardSeverityRulesID,
+	DashboardSeverityRulesPriority,
+	DashboardSeverityRulesDashType,
+	DashboardSeverityRulesApp,
+	DashboardSeverityRulesEnv,
+	DashboardSeverityRulesObjectPattern,
+	DashboardSeverityRulesSeverity,
+	DashboardSeverityRulesUpdated,
 	DigitI,
 	DiskinfoID,
 	DiskinfoDiskID,
@@ -2660,6 +2708,13 @@ var AllCols = []*Col{
 	Oc3SchedulerTaskName,
 	Oc3SchedulerIsDisabled,
 	Oc3SchedulerLastRunAt,
+	Oc3SchedulerRunsID,
+	Oc3SchedulerRunsTaskName,
+	Oc3SchedulerRunsBegin,
+	Oc3SchedulerRunsEnd,
+	Oc3SchedulerRunsStatus,
+	Oc3SchedulerRunsError,
+	Oc3SchedulerRunsRowsAffected,
 	PackagesID,
 	PackagesPkgName,
 	PackagesPkgVersion,
@@ -3072,6 +3127,7 @@ var AllCols = []*Col{
 	SvcmonMonSmonGlobalExpect,
 	SvcmonMonFrozenAt,
 	SvcmonMonEncapFrozenAt,
+	SvcmonMonMergeRule,
 	SvcmonLogID,
 	SvcmonLogMonOverallstatus,
 	SvcmonLogMonIpstatus,
@@ -3128,6 +3184,13 @@ var AllCols = []*Col{
 	SwitchesSwUpdated,
 	SwitchesSwFabric,
 	SwitchesSwIndex,
+	SysreportLogID,
+	SysreportLogNodeID,
+	SysreportLogCommitID,
+	SysreportLogCommitDate,
+	SysreportLogMessage,
+	SysreportLogChanges,
+	SysreportLogUpdated,
 	SysrepAllowID,
 	SysrepAllowPattern,
 	SysrepAllowFsetID,
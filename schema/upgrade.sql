@@ -0,0 +1,58 @@
+-- Schema upgrade of the collector database for the oc3 features.
+--
+-- The statements are idempotent, apply them to the collector database
+-- after each oc3 upgrade:
+--
+--   mysql -u root opensvc < schema/upgrade.sql
+
+-- clusters: the last daemon status update time, and the removal of the
+-- rows keyed by the cluster name saved by the previous workers.
+ALTER TABLE `clusters`
+  ADD COLUMN IF NOT EXISTS `updated` timestamp NOT NULL DEFAULT current_timestamp();
+DELETE FROM `clusters` WHERE `cluster_id` = `cluster_name`;
+
+-- svcmon: the rules merging the hypervisor and encap statuses of a
+-- container instance.
+ALTER TABLE `svcmon`
+  ADD COLUMN IF NOT EXISTS `mon_merge_rule` varchar(255) DEFAULT '';
+
+-- sysreport_log: the node sysreport commits.
+CREATE TABLE IF NOT EXISTS `sysreport_log` (
+ `id` bigint(20) NOT NULL AUTO_INCREMENT,
+ `node_id` char(36) CHARACTER SET ascii COLLATE ascii_general_ci NOT NULL,
+ `commit_id` char(40) CHARACTER SET ascii COLLATE ascii_general_ci NOT NULL,
+ `commit_date` datetime NOT NULL,
+ `message` text DEFAULT NULL,
+ `changes` int(11) DEFAULT 0,
+ `updated` timestamp NOT NULL DEFAULT current_timestamp(),
+ PRIMARY KEY (`id`),
+ UNIQUE KEY `k_node_commit` (`node_id`,`commit_id`),
+ KEY `k_node_commit_date` (`node_id`,`commit_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci;
+
+-- dashboard_severity_rules: the dashboard alerts severity overrides.
+CREATE TABLE IF NOT EXISTS `dashboard_severity_rules` (
+ `id` int(11) NOT NULL AUTO_INCREMENT,
+ `priority` int(11) NOT NULL DEFAULT 0,
+ `dash_type` varchar(60) NOT NULL DEFAULT '',
+ `app` varchar(64) NOT NULL DEFAULT '',
+ `env` varchar(10) NOT NULL DEFAULT '',
+ `object_pattern` varchar(255) NOT NULL DEFAULT '',
+ `severity` int(11) NOT NULL,
+ `updated` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+ PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci;
+
+-- oc3_scheduler_runs: the scheduler task executions history.
+CREATE TABLE IF NOT EXISTS `oc3_scheduler_runs` (
+ `id` bigint(20) NOT NULL AUTO_INCREMENT,
+ `task_name` varchar(255) NOT NULL,
+ `begin` datetime(6) NOT NULL,
+ `end` datetime(6) NOT NULL,
+ `status` varchar(16) NOT NULL,
+ `error` text DEFAULT NULL,
+ `rows_affected` bigint(20) DEFAULT NULL,
+ PRIMARY KEY (`id`),
+ KEY `k_task_name_begin` (`task_name`,`begin`),
+ KEY `k_begin` (`begin`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci;
@@ -51,6 +51,30 @@ type ServerInterface interface {
 	// (POST /auth/node)
 	PostAuthNode(ctx echo.Context) error
 
+	// (GET /clusters)
+	GetClusters(ctx echo.Context, params GetClustersParams) error
+
+	// (GET /clusters/{cluster_id})
+	GetCluster(ctx echo.Context, clusterId string, params GetClusterParams) error
+
+	// (GET /clusters/{cluster_id}/heartbeats)
+	GetClusterHeartbeats(ctx echo.Context, clusterId InPathClusterId, params GetClusterHeartbeatsParams) error
+
+	// (GET /clusters/{cluster_id}/status)
+	GetClusterStatus(ctx echo.Context, clusterId InPathClusterId) error
+
+	// (GET /dashboard/severity_rules)
+	GetDashboardSeverityRules(ctx echo.Context) error
+
+	// (POST /dashboard/severity_rules)
+	PostDashboardSeverityRules(ctx echo.Context) error
+
+	// (DELETE /dashboard/severity_rules/{rule_id})
+	DeleteDashboardSeverityRule(ctx echo.Context, ruleId InPathRuleId) error
+
+	// (PUT /dashboard/severity_rules/{rule_id})
+	PutDashboardSeverityRule(ctx echo.Context, ruleId InPathRuleId) error
+
 	// (GET /disks)
 	GetDisks(ctx echo.Context, params GetDisksParams) error
 
@@ -105,6 +129,12 @@ type ServerInterface interface {
 	// (GET /nodes/{node_id}/interfaces)
 	GetNodeInterfaces(ctx echo.Context, nodeId string, params GetNodeInterfacesParams) error
 
+	// (GET /nodes/{node_id}/sysreport)
+	GetNodeSysreport(ctx echo.Context, nodeId string, params GetNodeSysreportParams) error
+
+	// (GET /nodes/{node_id}/sysreport/{commit_id})
+	GetNodeSysreportCommit(ctx echo.Context, nodeId string, commitId string) error
+
 	// (GET /nodes/{node_id}/tags)
 	GetNodeTags(ctx echo.Context, nodeId string, params GetNodeTagsParams) error
 
@@ -114,6 +144,12 @@ type ServerInterface interface {
 	// (GET /openapi.json)
 	GetSwagger(ctx echo.Context) error
 
+	// (GET /scheduler/failing_tasks)
+	GetSchedulerFailingTasks(ctx echo.Context, params GetSchedulerFailingTasksParams) error
+
+	// (GET /scheduler/runs)
+	GetSchedulerRuns(ctx echo.Context, params GetSchedulerRunsParams) error
+
 	// (GET /services)
 	GetServices(ctx echo.Context, params GetServicesParams) error
 
@@ -547,6 +583,256 @@ func (w *ServerInterfaceWrapper) PostAuthNode(ctx echo.Context) error {
 	return err
 }
 
+// GetClusters converts echo context to params.
+func (w *ServerInterfaceWrapper) GetClusters(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClustersParams
+	// ------------- Optional query parameter "props" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "props", ctx.QueryParams(), &params.Props)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter props: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "meta" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "meta", ctx.QueryParams(), &params.Meta)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter meta: %s", err))
+	}
+
+	// ------------- Optional query parameter "stats" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "stats", ctx.QueryParams(), &params.Stats)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter stats: %s", err))
+	}
+
+	// ------------- Optional query parameter "orderby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "orderby", ctx.QueryParams(), &params.Orderby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderby: %s", err))
+	}
+
+	// ------------- Optional query parameter "groupby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupby", ctx.QueryParams(), &params.Groupby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupby: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetClusters(ctx, params)
+	return err
+}
+
+// GetCluster converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCluster(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "cluster_id" -------------
+	var clusterId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cluster_id", ctx.Param("cluster_id"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cluster_id: %s", err))
+	}
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClusterParams
+	// ------------- Optional query parameter "props" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "props", ctx.QueryParams(), &params.Props)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter props: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "meta" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "meta", ctx.QueryParams(), &params.Meta)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter meta: %s", err))
+	}
+
+	// ------------- Optional query parameter "stats" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "stats", ctx.QueryParams(), &params.Stats)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter stats: %s", err))
+	}
+
+	// ------------- Optional query parameter "orderby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "orderby", ctx.QueryParams(), &params.Orderby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderby: %s", err))
+	}
+
+	// ------------- Optional query parameter "groupby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupby", ctx.QueryParams(), &params.Groupby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupby: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCluster(ctx, clusterId, params)
+	return err
+}
+
+// GetClusterHeartbeats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetClusterHeartbeats(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "cluster_id" -------------
+	var clusterId InPathClusterId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cluster_id", ctx.Param("cluster_id"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cluster_id: %s", err))
+	}
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClusterHeartbeatsParams
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetClusterHeartbeats(ctx, clusterId, params)
+	return err
+}
+
+// GetClusterStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetClusterStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "cluster_id" -------------
+	var clusterId InPathClusterId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cluster_id", ctx.Param("cluster_id"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cluster_id: %s", err))
+	}
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetClusterStatus(ctx, clusterId)
+	return err
+}
+
+// GetDashboardSeverityRules converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDashboardSeverityRules(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetDashboardSeverityRules(ctx)
+	return err
+}
+
+// PostDashboardSeverityRules converts echo context to params.
+func (w *ServerInterfaceWrapper) PostDashboardSeverityRules(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostDashboardSeverityRules(ctx)
+	return err
+}
+
+// DeleteDashboardSeverityRule converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteDashboardSeverityRule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rule_id" -------------
+	var ruleId InPathRuleId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rule_id", ctx.Param("rule_id"), &ruleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rule_id: %s", err))
+	}
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteDashboardSeverityRule(ctx, ruleId)
+	return err
+}
+
+// PutDashboardSeverityRule converts echo context to params.
+func (w *ServerInterfaceWrapper) PutDashboardSeverityRule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rule_id" -------------
+	var ruleId InPathRuleId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rule_id", ctx.Param("rule_id"), &ruleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rule_id: %s", err))
+	}
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutDashboardSeverityRule(ctx, ruleId)
+	return err
+}
+
 // GetDisks converts echo context to params.
 func (w *ServerInterfaceWrapper) GetDisks(ctx echo.Context) error {
 	var err error
@@ -1590,6 +1876,105 @@ func (w *ServerInterfaceWrapper) GetNodeInterfaces(ctx echo.Context) error {
 	return err
 }
 
+// GetNodeSysreport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeSysreport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node_id" -------------
+	var nodeId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node_id", ctx.Param("node_id"), &nodeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node_id: %s", err))
+	}
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeSysreportParams
+	// ------------- Optional query parameter "props" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "props", ctx.QueryParams(), &params.Props)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter props: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "meta" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "meta", ctx.QueryParams(), &params.Meta)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter meta: %s", err))
+	}
+
+	// ------------- Optional query parameter "stats" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "stats", ctx.QueryParams(), &params.Stats)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter stats: %s", err))
+	}
+
+	// ------------- Optional query parameter "orderby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "orderby", ctx.QueryParams(), &params.Orderby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderby: %s", err))
+	}
+
+	// ------------- Optional query parameter "groupby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupby", ctx.QueryParams(), &params.Groupby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupby: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeSysreport(ctx, nodeId, params)
+	return err
+}
+
+// GetNodeSysreportCommit converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeSysreportCommit(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node_id" -------------
+	var nodeId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node_id", ctx.Param("node_id"), &nodeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node_id: %s", err))
+	}
+
+	// ------------- Path parameter "commit_id" -------------
+	var commitId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commit_id", ctx.Param("commit_id"), &commitId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter commit_id: %s", err))
+	}
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeSysreportCommit(ctx, nodeId, commitId)
+	return err
+}
+
 // GetNodeTags converts echo context to params.
 func (w *ServerInterfaceWrapper) GetNodeTags(ctx echo.Context) error {
 	var err error
@@ -1690,6 +2075,92 @@ func (w *ServerInterfaceWrapper) GetSwagger(ctx echo.Context) error {
 	return err
 }
 
+// GetSchedulerFailingTasks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSchedulerFailingTasks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSchedulerFailingTasksParams
+	// ------------- Optional query parameter "min_age" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_age", ctx.QueryParams(), &params.MinAge)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min_age: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSchedulerFailingTasks(ctx, params)
+	return err
+}
+
+// GetSchedulerRuns converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSchedulerRuns(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BasicAuthScopes, []string{})
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSchedulerRunsParams
+	// ------------- Optional query parameter "props" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "props", ctx.QueryParams(), &params.Props)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter props: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "meta" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "meta", ctx.QueryParams(), &params.Meta)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter meta: %s", err))
+	}
+
+	// ------------- Optional query parameter "stats" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "stats", ctx.QueryParams(), &params.Stats)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter stats: %s", err))
+	}
+
+	// ------------- Optional query parameter "orderby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "orderby", ctx.QueryParams(), &params.Orderby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderby: %s", err))
+	}
+
+	// ------------- Optional query parameter "groupby" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupby", ctx.QueryParams(), &params.Groupby)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupby: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSchedulerRuns(ctx, params)
+	return err
+}
+
 // GetServices converts echo context to params.
 func (w *ServerInterfaceWrapper) GetServices(ctx echo.Context) error {
 	var err error
@@ -2548,6 +3019,14 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/apps/:app_id/responsibles", wrapper.GetAppResponsibles)
 	router.GET(baseURL+"/arrays", wrapper.GetArrays)
 	router.POST(baseURL+"/auth/node", wrapper.PostAuthNode)
+	router.GET(baseURL+"/clusters", wrapper.GetClusters)
+	router.GET(baseURL+"/clusters/:cluster_id", wrapper.GetCluster)
+	router.GET(baseURL+"/clusters/:cluster_id/heartbeats", wrapper.GetClusterHeartbeats)
+	router.GET(baseURL+"/clusters/:cluster_id/status", wrapper.GetClusterStatus)
+	router.GET(baseURL+"/dashboard/severity_rules", wrapper.GetDashboardSeverityRules)
+	router.POST(baseURL+"/dashboard/severity_rules", wrapper.PostDashboardSeverityRules)
+	router.DELETE(baseURL+"/dashboard/severity_rules/:rule_id", wrapper.DeleteDashboardSeverityRule)
+	router.PUT(baseURL+"/dashboard/severity_rules/:rule_id", wrapper.PutDashboardSeverityRule)
 	router.GET(baseURL+"/disks", wrapper.GetDisks)
 	router.GET(baseURL+"/disks/:disk_id", wrapper.GetDisk)
 	router.GET(baseURL+"/nodes", wrapper.GetNodes)
@@ -2566,9 +3045,13 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/nodes/:node_id/disks", wrapper.GetNodeDisks)
 	router.GET(baseURL+"/nodes/:node_id/hbas", wrapper.GetNodeHbas)
 	router.GET(baseURL+"/nodes/:node_id/interfaces", wrapper.GetNodeInterfaces)
+	router.GET(baseURL+"/nodes/:node_id/sysreport", wrapper.GetNodeSysreport)
+	router.GET(baseURL+"/nodes/:node_id/sysreport/:commit_id", wrapper.GetNodeSysreportCommit)
 	router.GET(baseURL+"/nodes/:node_id/tags", wrapper.GetNodeTags)
 	router.GET(baseURL+"/nodes/:node_id/uuid", wrapper.GetNodeUUID)
 	router.GET(baseURL+"/openapi.json", wrapper.GetSwagger)
+	router.GET(baseURL+"/scheduler/failing_tasks", wrapper.GetSchedulerFailingTasks)
+	router.GET(baseURL+"/scheduler/runs", wrapper.GetSchedulerRuns)
 	router.GET(baseURL+"/services", wrapper.GetServices)
 	router.GET(baseURL+"/services/:svc_id", wrapper.GetService)
 	router.GET(baseURL+"/services/:svc_id/candidate_tags", wrapper.GetServiceCandidateTags)
@@ -2589,54 +3072,84 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xdzXLbOBJ+lS7uHpIqRVImnsO4ag4ZZ5L1bn68crJ7SFwuiGxJmJAAA4C2tS69+xYA",
-	"gqItUKTkWJYTnFwWm+gG8H39AwLkdRTzLOcMmZLR4XWUE0EyVCjMf5SdEDV7J1EdJ/r/BGUsaK4oZ9Fh",
-	"dPwK+ATUDCHjSZGiRBX1Iqov5UTNol7ESIbRYZRJVOc0iXqRwG8FFZhEh0oU2ItkPMOM6KbVPNeiUgnK",
-	"ptFi0SuVv+cJrlfOeIJ+vfrKtnpHrZ0W67ostuzyvwsU8zeCF/l4vqr8iGcZeSZRz5LCBFIqlTYnFzxH",
-	"oShKUBym+nZrIsoiVTCewxPsT/v2ynj+O8nznryIta1P+64D37TqZQ9K2aiTxW9pRtWqvR81NsgVzYoM",
-	"WJGNUWhrkSlRmipQFYL1YQgZEiaBcUh1U01GmYs3TEpwQopURYe/DntRRpnWFR0Oe85WyhROUdSNfYeK",
-	"eCaWxWmRIGSoSEIUAcrcGOacSezDn4yMU0z0cJZa+/BJIkxIKhG4gKHuEs+osqRARWBCMU2aeqMluo3v",
-	"h8lEQ23F6NOv1M70hAqpqpEtEWq6ERdCctFkArcNe0e084B+EAmK7fEqudAY7cOJwAm9AuKuz+GSqhk8",
-	"gwkXoFtGllA2Ba71lZDmVvfvmuu6T71nJM8bQV1Kdxv0E8Fzudqplw3doCWAKAMk8cyOfkJjfRsjYt5k",
-	"U27UdLLoVBElfcPMlOCpNJNuzJCUsyWANccwqduirSfwJZK6wS8RfMV5D2LOFKFMj7C+T2KKsZ61WjcT",
-	"KhVlsYILkhYoIeYFU7KpZ6b1tT1baP9o+WX6dTAc6j/aEmQG7yTPUxoTbfjgL6m7e11r7+8CJ9Fh9LfB",
-	"MooN7FU5OBF8nGJmtdwcsD9IAiP8VqBU0aIXHQyf70LrJ0YKNeOC/g8Tq/bFLtS+5mJMkwSZ1XmwC53v",
-	"uYLXvGBlP3/bhc4jziYpjc2M/robHB0zhYKRFE5RXKCAP4Xg1jOWN+u231KpXMhZUsnYdEFoqmPKee5c",
-	"DVWYSQ9TKvdLhCBz/b+hXk2y8su9yLHU6EgSqq0l6ckN3at3lb/w8V9oB7F0aMk21qUuIVjVw6tY5rGB",
-	"K5L6Lvns0wM7Kr3H6uBq96v/coYfJtHh51Xrly3dsr551O4wmrd+OFv0bPxvQV+FHusrXS752fbvzKPI",
-	"4XVlRBReKV84mxUZYc8EkkRjEfAqTwkzTAGZY0wnNNbxTc2oBB7HhRDIYiyTjC8st/r6X1jU84Suus3G",
-	"Ap/NFygktaS8aXPtAl6RLE/1fcP+sP+8VZm7dVWf5ifGhaBqfqqH2aoaE0njl4WaVa5B32N+XeqaKZVr",
-	"g8dIBAonbf97zUVGVHQY/fO/H13UM02Yq7fbsGF9ws3MUGU6xnNk8iKGmKc69nIBJKdRbXii5/1h/4Vh",
-	"UY5MXzyMXvSH/WHUMwWI6ciA5JauU1/KqAEFlecBI2uaE2bKdc0TvUH10v5erwc/+5G6FBncSJw0j7rJ",
-	"2+qhu3yZDXe/wRKos7hNszawp8wqu9/hKrzF2a0E6JfvGLhu+EdP9Prwr1qo9DVUWTbQQnXaGDDUCPP5",
-	"TPe9TorPZ7pvikw1cKIK0JH2ezmXHmAeCSQKgTCodRliW93fxOcJlw6gwqZxf/BkvtHA3YrEee4NayTP",
-	"zxOeEcoaLysk2XkZH1cEbvTwusVhaSM8zmpxew1hcUfMeBT4gHHQBRhaaJlBt8k+r6W9bbIvaqljm+xv",
-	"DwLkRc+62sG1xgFNFhbTKSpcRfcr83sndFtRvwO+FbvzHATGXCRAEzDhIndtehalrJkbrUmd7Qpr94Wf",
-	"gy6yB3uPtZ4/mL+iMk/J3Mx7zaP54/nDo6m3YQaxM/R1R8n+hMsZYVOvQ1mHhDJy7oljedShO8Tp7fzs",
-	"w8fpAcnO6XmpjI5TbCyWRmbtFPRsAi0f+1jUogAqodaGWaQ2NXJrgLfu+GV2PKqZ8KOE+jHnKRK237F+",
-	"DzCYF2M3mG3Fus/FL++2T/Wagv5JXc2jSwDCEsJjWEJ4ZMyrOe0tmFd3+WuZN6qrCcwLzPspmScEmbew",
-	"TCouiC5lrKyPTe5KWI4Oy9H3gdJCzQZmH5kuL73l9gin1GT9xGw4A30LMlUOhXn25am0C7uB7d7Xqd3u",
-	"l/ZF5krSt9J814r1prHV063b1hYFTdotNVLlMzL/svitGBrHmKsbWyv2Z43Z4LE7QDW6SmwmVH5tcaBW",
-	"xOM3X5UXgtsMbvMe3KbB3eBa/3GPP5pB6vYmTnR2u9xYoG9ugm5b1qxlgCbaDU+oecLuyZVL60KyHJLl",
-	"fU+WdWxucfVWxMOX9+WF4OqDq78vaA5mY9K2YpKmFqPw+ggIS4CeHp0ew4xLBeNCAklIbsDZBOF/aBUB",
-	"xgHG9wjj6/Jg0HYZC2t4pFMWemszFi1Ty1jgSWkJfPp0/Aq4AFeePf2OJ5oCfUIy8+BUG8SEJTQhCs/t",
-	"HeuYpyWAKEXimdmkqrhbdnnCuII5qvIqJibI6B/xyu5Wf9rEzSNnwEetPxA1EDUQ1UtUnuUpJSzGGmer",
-	"E77NzH2DCqoblkeCXfzU7XuWSR05K6UVTd8tVW6eD9aODgde/WC8elsev/SBrcY4j0PXcWLijqWVZGs4",
-	"0SXtiS5cnujaJd3EZmQTd6TaKBAtEK0L0cSPQLOUT1uIVcmClt2QVW/5dNdEuitIup6M9B26XR2q6qUc",
-	"jxkkHROeWppTVSRVtdIFLSHLCc53rfOtYPWDJDnLbgyuyxcDtRyc0v0Hsuw/TATPmilmj081sGwHJKu9",
-	"MskDIM+sVcaBLOIYpZwUaTqHBO3Er59tLmoD89BT33RW5aVamcN1TvKES7VH87fdxp0tjof8sg4bzg/s",
-	"y5GPfVur6FQyibsF61AnhVDdJVT/EGWSqMK02CRMi62D9GinLn60SYge3SlAi8cSnsVWwfkB5+0hQ/Mo",
-	"BOZmP9J116p9EV2nZ+sNm1nDc7sQzsNzu2TRZXOWfYbuztt03pTl35MViBeIF4iXLAZUJ20TEmM3+jFU",
-	"l1x8hdptDbw7rksE9gX2Bfatsm+j3WT1BZ8m1oXtYYFvgW+NfHOHN9e+j8wEOi3Zhw8sLf+vv3fBbNvM",
-	"CCNTFBKIQCBpyi/RvCvfS0vNrH2k5U/0hrwHwV/5otu+G7kSdysYOb0k06k5BPjgjN6DdR03mvY9QeVQ",
-	"ShQXtDVNraQ8RDxdXgsndMIJnXugu0Pf4FpexFuf0SlbWQPhtlBSit2IJtaiKpi4L+j4Y4kVDhleyPD2",
-	"PcKuUO7uZ3XKJrc9rlOSb6MTO4GwgbA/LWG3WgRpD5KBd4F3gXcrvDunTCrCOldSsJRfU1Md14RCcRWK",
-	"q50AuGOZVck3FFrwpHrxx9MuGA9BJfAsBJUGTp5LRVQhz1M+3TS+gL0VUj7tw58knpkFkjlQCQQUzRCE",
-	"+WrE5QwF1uo014C7/5KYpsYp9juFrFNz21s+DbErxK774Ul7iYNX5ruXU1Pr+GDrL2cCQANAvxdAu7zC",
-	"T2dKikyf2dcIm4o8M5/fb0BseLNfgO19w7bbk0GHXJc1dABveGAY8LsD/F4rMl1bxbqNKYpM3efR5g2Y",
-	"batNdflpPwWljLCn+rTWdKk+ax+4Dt/Gu/v8t8TfN1i+QbcsgMpJNOWRO8XjB0VDEN5LZNy7s3y41424",
-	"k5blJFI1s59c0+PddArvI5l6T949JEw324mzMVqbo+7PCdgQ3X+GlbTqK/kNnBL2Y44kp+BEPfT5T3Xp",
-	"3sbbaf8+iVQ1HCQnY5pS8/mVs4UdWXHhmF+INDqM+oNocbb4fwAAAP//b3zgBvaNAAA=",
+	"H4sIAAAAAAAC/+xdX3PbOJL/KijePexc0ZJnxrt166p9yCSTjO8yic/O3D5EKRVEtiRsSIADgI51Ln33",
+	"qwbAP5JAiZIt20nwlFhsAI1G/7rRQAO4ixKRF4ID1yo6v4sKKmkOGqT5i/FLqucvs1JpkBcp/pSCSiQr",
+	"NBM8Oo8uXhExJXoOJLFEURwx/FBQPY/iiNMcovPIfRyzNIojCX+WTEIanWtZQhypZA45xbr1okBqpSXj",
+	"s2i5jB0DvyvQ21vPRVpmoED7288V6EMbfydS2N44Fyn428Uvh7Z7tbPTcluX5T26fFVmO7qcUjWfCCpT",
+	"ouAGJNMLw00HK2W2UwpTIXOqo/OIcf23syiueGNcwwykY+5/SpCLN1KUxWSxyd5Lkef0RAHqsIaUZExp",
+	"ZLiQogCpGSiiBZlhcSs/UGWmyWRB/gKD2cB+mSz+QYsiVjcJcv/DoOrSn9h00ydHG+0Sp+H4LcuZ3uT3",
+	"AyouvWV5mRNe5hOQyC1wLR2rEnQp+YCckhwoV4QLkmFVXUyZjysspTClZaaj87+exlHOOLYVnZ9uE+/v",
+	"oKln6HmSlSmQHDRNqaaE8UqGheAKBuRXTicZpChO1+qA/KGATGmmgAhJTrFLImfaIhY0JVMGWdrVG6To",
+	"J9/306kCj4CvPzM70lMmla4lW+uwpiQppRKyiwVhK/ZKtLdA38sU5OH6qoREHR2QSwlTdkto9X1BvjA9",
+	"JydkKiTBmoGnjM+IwPacSgvb9j/QEGGf4hNaFJ1K7aj7Cf1SikJtdupFRzeYUyDGCdBkbqWfsgSLcSoX",
+	"XTwVppleHF1rqpVPzFxLkSkz6IYNxQRvFBgxBmmbF+SeklGksMJRRD7DIiaJ4JoyjhLGcgoySHDUWt1M",
+	"mdKMJ5rc0KwERRJRcq26emZq39qzZRxV+DL9Ojs9xX+QE+BG32lRZCyhyPjwXwq7e9eq798lTKPz6N+G",
+	"jY8f2q9qeCnFJIPctrIqsF9oSq7gzxKUjpZxdHb642O0+genpZ4Lyf4PUtvsz4/R7GshJyxNgds2zx6j",
+	"zXdCk9ei5K6ff3+MNl8KPs1YYkb0r4+jRxdcg+Q0I9cgb0CSX6UU1jK6wli3m1z+BlTqCTgAN5gyfDaz",
+	"x02QxGaSpfze1ZU0MzRFKE8tdDXV4LwAk2TeNB1HTEOudvV5g2ecIyIrjjcqJV3g3wWA7GANP5Gcaslu",
+	"YyI4GO+0IIXj1jBbQPVXQZk8mLtLALnJ3bI9Hfu4OkW3Iq34/1QXFZN/gVUhvwg2hm6+Mqy92K+r9Am0",
+	"mlB36YE1rT4f0e5rMy2vy8RtVnt12Eh1o8P4CZvs28lfHP0yfi6ysqM+3la6JriHuFcaWa8yruW4c1xe",
+	"VZHItQtEMHgxJi3L3k+j84/bJegtfsGLEitfH1yW9g1V2t1nqYfxT12s27a9JqMOtTaCMJqB1PXE1rah",
+	"0LokczdfGXGMwgYE64G80AuSSKZBMmrJCM2yAfnnHDjJKbfRnKshroPNETczTvxzzmZzUJoUkgnDE1PE",
+	"OBFIByMexWuSo0Wx2aeGVywaxetqFEfYw7H91Vd4TQAEKWOSsc+AkzeQNywBUnJ6Q1mGwckoiuIIbmle",
+	"ZKadTQofE8BvtvKO39vVXl698lVjqccF1Rok99c4y8SEOIrV0SQOF00zf9KT//C1U43IerCyrqVxVOkT",
+	"Uu4IZdr6XBfzwbGxSA9pGlFSXkuUSnYD0vtpzpQWctEBJTMFQB/ruHK/FCCZSFWMUewEZoxjnAJ9PW/N",
+	"+qWpxmeUM6r0uCWJ2phgOyea5V4V7DTVhmvvl7LAGtMe1tkqlpOkk3VVc9sQr7DeNNBIeqs+/NL0GTgq",
+	"2seID+lKA1zoajhadTm24+j2BAue3FCJLCusYb32dy+iVpMX6pe67vrHd6Jm5VObPzdkD6m1RoH6DzLw",
+	"tD9x18CvY3VjFC1TtjXfgL1lSldLQGs2vDKS46IK/WtUbPC3rvcmFG5RtsxQFTWbNtKUIUxpdrnS9map",
+	"DcbdAkN6CHdZtUC32Y6o15Y2v2mhaeb7tOwQ7JWL5jeFi8sh+K/g4GYt69w3Na1x3y21e0jTM13JnVZs",
+	"g0GtPeuKaPrn07cqftyQiIZb7Vtempc55ScSaIq6SOC2yCg3kStRBSRsyhJcb9JzpohIklJK4EkV7o14",
+	"Yduzk5Tt4DEc+Hi+TuaA+w3yNWUZ47MPVH3e7MCUsgzSsSx5RwjYrPlaUgK3kJRIoIhiyDT6fzS7RJVJ",
+	"AkpNy6whiuLdU9HYcMH4bGwq9PNhHZ2YtpZK1xkidKpB9udnq+0yjgTMYsD5XcdnZKCUfRg2/Kzzux8v",
+	"rjd9G/N1PiZ0ooBrwqaECw7V8mLlG/vyo6n6PO4XVjWk66O8JsN4RRVX5O/V7oWSUAipcX2aeSZyifm9",
+	"KzhM2XTaseRhAg3GtRRpmditArN1aNuJ/WGqv53OELNhzrHi6+INSMUE3+xa60Mz0z4dnA5+3GktqqKb",
+	"7ZmpdlLinBktR+4mFlSx5EWp5/VaG5YxvzZtzbUu7FSCSpAVtf3rdaVQ//XPD9UysqnCfF2vw66TT4WR",
+	"JdOmY6IArm4SkogMF7OFJLRgUUs80Y+D08HPxg0WwPHjefTz4HRwGsVmj890ZEgL629nvj0Y9AiknjoQ",
+	"Q2uqk8Zm4zZj9Ab0C/t7e/u5I3JvSIYrOxHLuC+93Y7rT++2l/oXsB6wN7ndt9iDH7dN079EtWW6/LS2",
+	"o/DTA64Er0xwPMvB7/+7tfbsq6jmbIhEbdgYZWgB5uMn7HsbFB8/Yd80nZm4oFZoM8cvhPIo5ksJNgYk",
+	"rS6TxO7lr+rnpVCVgkq7L/KLSBd7Cc67HLJh72hRjFORU8Y7P2ug+dhNcDdtb7uHu2wmMuExVsv1bfrl",
+	"PXXG04BPMc76KAYSNVtSu2h/bO0j7aL9ubUXs4v270+iyMvYmtrhHeoBS5dWpzPQnmnSK/N7L+22pH4D",
+	"vDb5LgoiIREyJSwlxl0UVZ2evA/L5l4ZKJ8eS9eOpT9nfWjPnr2uxX5n/oqpIqMLM+4ti+b350+vTfGe",
+	"M4hH077+WvJ83OWc8pnXoGzTBOc5n4lh+apdd/DTh9nZp/fTQ5qP2dg1xiZ2e9JrX69MMhLB0cSFBJuf",
+	"ZLQWJO6wteowWV9mkWung7fm+EV+cdUU/2Zc/USIDCh/3r7+GehgUU4qYe4K1n0mvilt02S7nP5lu5mv",
+	"bgIQlhC+hiWErwx5LaN9APLaJn8r8q7azQTkBeR9l8jDPdEdKFNaSIqhjKX1oan6Epajw3L0MbS01PMh",
+	"rxJmveH2FcyYmfVTlw5c6jlw7UQx4htqayLt0h5XO/o69X65n4bSt9J834h1ldl6d2ud27Lss4VoqNwe",
+	"mX9ZfM2HJgkUeuWswvNZY17GeykoapfTTZcHvsOGtk58qphUWaHMZdrHVQpqbDfMUwq54CbFrlQjrllu",
+	"c++a1F6iyjzHU0DkPc8WK9Xb2h0M8EOpQI64JxqmEszhI0hjUvIMFCawcjoDOfAA5g3ol1Vfg6X/HuYu",
+	"/dcBnsQrVAo/vGvOYiw7YVgvxbdOXncp+K5wwJERlgLXbMrw+GDDA/njDzz4K5vTNHgw9mEPegfEfeeI",
+	"e/aRhRedw9UzO1uB2vJpLcd3bn5vO8LqcJo5JYtOLx7x6uRa69BY7XTXMtirknWVZCpFTuiIWwcqCLXV",
+	"1KfMWN2qS1ZrOeH22gUR0xGnK2fq6iN1zs8q44Nplokv1TmQLpv0W/vU3b7ed/VWimW8LnOX4i91lbe3",
+	"KiPXz7g6qG4OKP90RuaixC7MRNeJXZdd57m2YEtS3/LTg05zq0zhvY4A9s3HDYvovWBvZ7K9IG8mwJJ+",
+	"WZ0Ek0IobTMg3cx29RqVeMTN/o4UJUJvLgSqrz2+9ZYqffK7SNFRmzl0ajeHqqOtpSL2bIadaXejmfQA",
+	"84j3QvO1Fci9kfwd5X88iTrXJ+aG1SmusTnptzvaWz9rt3Lviz0wlUIigSq0s9UhNKOxI54IPmWzUkJq",
+	"yY2b4EK7cK1Dt7znI20S3oNb017nu7wM7TxZfURT++ySKberibVfX4T8jNZFQiZo2txkNOK452HSTAYd",
+	"C1xbNOKw5a5DjwY/fPbFAXr3nDM0npV1G965K6h6pUweUYdtE/7hPMx3uqu6guM8rtErvUv0RUaTIxu9",
+	"Uh9NW4LFDDlte1lXpj7vmChaEt9Uzn0Iy+xhQ/VYqjm8w3+2LpobJa2uATRbRs2ZYSzcpbq7FtCRprV6",
+	"7l8bd9yFhfGwMP7cTX19pVo3iiyJBy/v3Idg6oOpP5ZqDucTuiuXMsvctX+vX9ptjuuX1xdkLpQmk1IR",
+	"mtLC7f37Vfi3CQ1qHNT4qGp85+4tOGzGwjsOe7gUsK0zFqRZ2e93nNSb/VXi1g8PeLN5gE+YzDw51IYJ",
+	"5SlLqYaxLbF154POFKFa02Rurq8w2+dYEwJGkwVo9xVS42TwR7i1F1H90IXNlxUDH7D9ANQA1ABUL1BF",
+	"XmSM8gRamK1f+uhG7hvQpC7QPA1S+U+s37/haMBZN1rD9PemycOWXd0TIgFX3xiu3rqXDnzK1kKcx6Cj",
+	"n5hWN8A7sHVcnq7s5enQXJ7+mHCT+4FN3hNqVwFoAWh9gCa/BZhlYrYDWDUtQdo9UfVWzB4bSPdVkr6X",
+	"nvret9gUVf0419esJD0nPA1ZE5FosYe2hFlOML5bjW+tVt/IJKfpxvDOPRC4Iz8I+09o03+XWt8FMZvt",
+	"04GyRwBZ6+lEjwJ5Rq1mrnXFbYZ5nXbgt4+2kC3BPPXQd+UpvtAbY7jNSGLO4TMav8Mydg64OOqnbbpR",
+	"2YHvOtVwi13pFTLJ+znrECcFV93HVX8TYZKs3bTcx03Lg5301aOa+Kt9XPTVvRy0/FrcszzIOT/huD2l",
+	"a74KjrnbjvTNWrXn4XrtrXcks4Z9u+DOw74dHorfnZxl99Crm7h6J2X5c7IC8ALwAvDS5ZBxDXJKE+gH",
+	"Pw4ajyWRVrEO3F20KQL6AvoC+jbRp6p3s3Yfpq9J3dtXbvaJVcUkR28oIQGu7WtsXaisH+oKoAygDKDc",
+	"DsrhXf0yXHe29fVcfDEAtSdyW7DcgOxOUL6syJ4jNNe6vdY3wtIY26Yc38bOJ2xWCryzRsKU3RqZaJIB",
+	"VZqcoaQkTdyk3cNg+z2+x3scYJumr4/QA50K/sqAsVfuc3t7okvxQzJzcETBEXXirbqEeOuFYcbTIKW7",
+	"tIuvvQCgzCED72V7XbBEZD1HWIYLS46rf+7B1kElOad3Gzpy/YXOZiCjr+y20CPtQlTStO/dOFGq6rnv",
+	"YfXMMj693OPOtLogMQXIl7lQ0H7l2z1bXT/2zaS5L3DE/U9MY50iS8E9eF1K91x364q/yjaM+O6bOH3P",
+	"mO904XiZTc44y8ucpKWtsH473DIVk4x9BvLT2Rxtx49/+895jNOHjGHPRMWpFYmTqBNBPa/MGR/TGQzI",
+	"q9YNnae2H76bOR19dEyLc4+L43ySfvh7477THb8GntVT+/ugsg3H+l5Yg6T1ZZjBiH8woSHLUgm8XRCx",
+	"hpqYklE0Kk9Pf06wavM/OCf2F1PO/jSK7KVQrr0Rl6CBGyQxtc6jZLlltCa6L9yvSh5OtocLuL82mIO8",
+	"YTu3NmoqHwCab0H3w60OR9TR4Z26SQ6+18HVskWFd03SHNlKTGc5qkM6dZNsiegscVhnCS7kuce5G5C7",
+	"//0OrspDr3hw4NvrlocA2ADY7xawB21F7HaSAXcBdwF3G7gbM6405b0jKdLQb4mpLlpEIbgKwdWjKHDP",
+	"MKum7wi0yF/qyyJ/6KPjwakEnAWn0oHJsX2CapyJ2b7+pXq9KhOzAfkVD4IB13KBa+KUmEdjJWZlkS9z",
+	"kNCK06oKqvJfqKlqksGgl8uyj1e9FbPgu4LvOg5Odoc4cMuUeaJQ27BlQ2394UxQ0KCgD6Wgfa59x5mS",
+	"prMT+2CgichzZK1LY8Nt8EFtj622/XYGK8111H2UN2wYBv19BP2903TW6613TfFlTVRVvejQ2V2x6cWr",
+	"KllL05k/+rTc9Ik+GdcwA3lA+Plo2Z/PPH5aGf8d/vcNuFdXXADkBtGER9XND36l6HDCz1Izjm4sn+6K",
+	"yup2HjeI9v12poy8u25u+UBn3ttanlJN98vE2Vtbu73u96mwwbt/DytpNyAVW0nVX+2GBF1KTmjBSEXq",
+	"gc//1p+OJu+q9YeZSNXioAWdsIxpBgolYiSLN09Z5Jcyi86jwTBaflr+/wB1t9Zzs8QAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -5,6 +5,7 @@ package server
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
@@ -14,6 +15,103 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for HeartbeatBeating.
+const (
+	HeartbeatBeatingNA  HeartbeatBeating = "n/a"
+	HeartbeatIsBeating  HeartbeatBeating = "beating"
+	HeartbeatNotBeating HeartbeatBeating = "not beating"
+)
+
+// ClusterHeartbeats defines model for ClusterHeartbeats.
+type ClusterHeartbeats struct {
+	ClusterId string `json:"cluster_id"`
+
+	// Nodes The cluster nodes and the state of their heartbeats
+	Nodes []ClusterHeartbeatsNode `json:"nodes"`
+
+	// Peers The peer matrix, one entry per node and peer node pair
+	Peers []ClusterHeartbeatsPeer `json:"peers"`
+}
+
+// ClusterHeartbeatsNode defines model for ClusterHeartbeatsNode.
+type ClusterHeartbeatsNode struct {
+	Heartbeats []Heartbeat `json:"heartbeats"`
+	NodeId     string      `json:"node_id"`
+	Nodename   string      `json:"nodename"`
+}
+
+// ClusterHeartbeatsPeer defines model for ClusterHeartbeatsPeer.
+type ClusterHeartbeatsPeer struct {
+	Beating      HeartbeatBeating `json:"beating"`
+	Heartbeats   []Heartbeat      `json:"heartbeats"`
+	NodeId       string           `json:"node_id"`
+	Nodename     string           `json:"nodename"`
+	PeerNodeId   string           `json:"peer_node_id"`
+	PeerNodename string           `json:"peer_nodename"`
+}
+
+// DashboardSeverityRule defines model for DashboardSeverityRule.
+type DashboardSeverityRule struct {
+	// App the object app
+	App *string `json:"app,omitempty"`
+
+	// DashType the dashboard alert type, like "service unavailable"
+	DashType *string `json:"dash_type,omitempty"`
+
+	// Env the object env
+	Env *string `json:"env,omitempty"`
+	Id  int64   `json:"id"`
+
+	// ObjectPattern the glob pattern of the object name
+	ObjectPattern *string `json:"object_pattern,omitempty"`
+	Priority      *int    `json:"priority,omitempty"`
+	Severity      int     `json:"severity"`
+}
+
+// DashboardSeverityRuleInput The severity of the dashboard alerts of the objects matching the
+// rule. The empty criteria match all. When many rules match, the rule
+// with the highest priority is applied.
+type DashboardSeverityRuleInput struct {
+	// App the object app
+	App *string `json:"app,omitempty"`
+
+	// DashType the dashboard alert type, like "service unavailable"
+	DashType *string `json:"dash_type,omitempty"`
+
+	// Env the object env
+	Env *string `json:"env,omitempty"`
+
+	// ObjectPattern the glob pattern of the object name
+	ObjectPattern *string `json:"object_pattern,omitempty"`
+	Priority      *int    `json:"priority,omitempty"`
+	Severity      int     `json:"severity"`
+}
+
+// Heartbeat defines model for Heartbeat.
+type Heartbeat struct {
+	Beating HeartbeatBeating `json:"beating"`
+	Desc    string           `json:"desc"`
+	Driver  string           `json:"driver"`
+
+	// History The state and beating state periods, by begin date
+	History     []HeartbeatPeriod `json:"history"`
+	LastBeating time.Time         `json:"last_beating"`
+	Name        string            `json:"name"`
+	State       string            `json:"state"`
+	Updated     string            `json:"updated"`
+}
+
+// HeartbeatBeating defines model for HeartbeatBeating.
+type HeartbeatBeating string
+
+// HeartbeatPeriod defines model for HeartbeatPeriod.
+type HeartbeatPeriod struct {
+	Beating HeartbeatBeating `json:"beating"`
+	Begin   time.Time        `json:"begin"`
+	End     time.Time        `json:"end"`
+	State   string           `json:"state"`
+}
+
 // ListMeta defines model for ListMeta.
 type ListMeta struct {
 	AvailableProps *[]string       `json:"available_props,omitempty"`
@@ -49,11 +147,40 @@ type Problem struct {
 	Text string `json:"text"`
 }
 
+// SchedulerFailingTask defines model for SchedulerFailingTask.
+type SchedulerFailingTask struct {
+	// FailedRuns The number of failed executions since the last successful execution
+	FailedRuns int64 `json:"failed_runs"`
+
+	// FailingSince The begin of the first failed execution after the last successful execution
+	FailingSince time.Time `json:"failing_since"`
+	LastError    string    `json:"last_error"`
+
+	// LastFailure The begin of the last failed execution
+	LastFailure time.Time `json:"last_failure"`
+
+	// LastSuccess The begin of the last successful execution, absent if none in the history
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	TaskName    string     `json:"task_name"`
+}
+
+// SysreportCommit defines model for SysreportCommit.
+type SysreportCommit struct {
+	CommitId string `json:"commit_id"`
+
+	// Diff The patch introduced by the commit
+	Diff   string `json:"diff"`
+	NodeId string `json:"node_id"`
+}
+
 // Version defines model for version.
 type Version struct {
 	Version string `json:"version"`
 }
 
+// InPathClusterId defines model for inPathClusterId.
+type InPathClusterId = string
+
 // InPathMsetId defines model for inPathMsetId.
 type InPathMsetId = string
 
@@ -63,6 +190,9 @@ type InPathNodeId = string
 // InPathRsetId defines model for inPathRsetId.
 type InPathRsetId = string
 
+// InPathRuleId defines model for inPathRuleId.
+type InPathRuleId = int64
+
 // InQueryGroupby defines model for inQueryGroupby.
 type InQueryGroupby = string
 
@@ -226,6 +356,60 @@ type PostAuthNodeJSONBody struct {
 	Nodename string  `json:"nodename"`
 }
 
+// GetClustersParams defines parameters for GetClusters.
+type GetClustersParams struct {
+	// Props A list of properties to include in each data dictionnary.
+	Props *InQueryProps `form:"props,omitempty" json:"props,omitempty"`
+
+	// Limit The maximum number of entries to return. 0 means no limit.
+	Limit *InQueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Skip the first entries of the data cursor.
+	Offset *InQueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Meta Include metadata in the response. Enabled by default. Use false or 0 to omit the meta field.
+	Meta *InQueryMeta `form:"meta,omitempty" json:"meta,omitempty"`
+
+	// Stats Controls the inclusion in the returned dictionnary of a "stats" key, containing the selected properties distinct values counts.
+	Stats *InQueryStats `form:"stats,omitempty" json:"stats,omitempty"`
+
+	// Orderby Comma-separated list of properties to sort by. Prefix a property with - for descending order (e.g. orderby=nodename,-app).
+	Orderby *InQueryOrderby `form:"orderby,omitempty" json:"orderby,omitempty"`
+
+	// Groupby Comma-separated list of properties to group the result by (e.g. groupby=app,svcname).
+	Groupby *InQueryGroupby `form:"groupby,omitempty" json:"groupby,omitempty"`
+}
+
+// GetClusterParams defines parameters for GetCluster.
+type GetClusterParams struct {
+	// Props A list of properties to include in each data dictionnary.
+	Props *InQueryProps `form:"props,omitempty" json:"props,omitempty"`
+
+	// Limit The maximum number of entries to return. 0 means no limit.
+	Limit *InQueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Skip the first entries of the data cursor.
+	Offset *InQueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Meta Include metadata in the response. Enabled by default. Use false or 0 to omit the meta field.
+	Meta *InQueryMeta `form:"meta,omitempty" json:"meta,omitempty"`
+
+	// Stats Controls the inclusion in the returned dictionnary of a "stats" key, containing the selected properties distinct values counts.
+	Stats *InQueryStats `form:"stats,omitempty" json:"stats,omitempty"`
+
+	// Orderby Comma-separated list of properties to sort by. Prefix a property with - for descending order (e.g. orderby=nodename,-app).
+	Orderby *InQueryOrderby `form:"orderby,omitempty" json:"orderby,omitempty"`
+
+	// Groupby Comma-separated list of properties to group the result by (e.g. groupby=app,svcname).
+	Groupby *InQueryGroupby `form:"groupby,omitempty" json:"groupby,omitempty"`
+}
+
+// GetClusterHeartbeatsParams defines parameters for GetClusterHeartbeats.
+type GetClusterHeartbeatsParams struct {
+	// Since The start of the beating state history, defaults to 24 hours ago
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+}
+
 // GetDisksParams defines parameters for GetDisks.
 type GetDisksParams struct {
 	// Props A list of properties to include in each data dictionnary.
@@ -550,6 +734,30 @@ type GetNodeInterfacesParams struct {
 	Groupby *InQueryGroupby `form:"groupby,omitempty" json:"groupby,omitempty"`
 }
 
+// GetNodeSysreportParams defines parameters for GetNodeSysreport.
+type GetNodeSysreportParams struct {
+	// Props A list of properties to include in each data dictionnary.
+	Props *InQueryProps `form:"props,omitempty" json:"props,omitempty"`
+
+	// Limit The maximum number of entries to return. 0 means no limit.
+	Limit *InQueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Skip the first entries of the data cursor.
+	Offset *InQueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Meta Include metadata in the response. Enabled by default. Use false or 0 to omit the meta field.
+	Meta *InQueryMeta `form:"meta,omitempty" json:"meta,omitempty"`
+
+	// Stats Controls the inclusion in the returned dictionnary of a "stats" key, containing the selected properties distinct values counts.
+	Stats *InQueryStats `form:"stats,omitempty" json:"stats,omitempty"`
+
+	// Orderby Comma-separated list of properties to sort by. Prefix a property with - for descending order (e.g. orderby=nodename,-app).
+	Orderby *InQueryOrderby `form:"orderby,omitempty" json:"orderby,omitempty"`
+
+	// Groupby Comma-separated list of properties to group the result by (e.g. groupby=app,svcname).
+	Groupby *InQueryGroupby `form:"groupby,omitempty" json:"groupby,omitempty"`
+}
+
 // GetNodeTagsParams defines parameters for GetNodeTags.
 type GetNodeTagsParams struct {
 	// Props A list of properties to include in each data dictionnary.
@@ -574,6 +782,37 @@ type GetNodeTagsParams struct {
 	Groupby *InQueryGroupby `form:"groupby,omitempty" json:"groupby,omitempty"`
 }
 
+// GetSchedulerFailingTasksParams defines parameters for GetSchedulerFailingTasks.
+type GetSchedulerFailingTasksParams struct {
+	// MinAge The minimum duration of the failure, like 24h or 168h, to list
+	// only the tasks failing since at least min_age. Defaults to 0.
+	MinAge *string `form:"min_age,omitempty" json:"min_age,omitempty"`
+}
+
+// GetSchedulerRunsParams defines parameters for GetSchedulerRuns.
+type GetSchedulerRunsParams struct {
+	// Props A list of properties to include in each data dictionnary.
+	Props *InQueryProps `form:"props,omitempty" json:"props,omitempty"`
+
+	// Limit The maximum number of entries to return. 0 means no limit.
+	Limit *InQueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Skip the first entries of the data cursor.
+	Offset *InQueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Meta Include metadata in the response. Enabled by default. Use false or 0 to omit the meta field.
+	Meta *InQueryMeta `form:"meta,omitempty" json:"meta,omitempty"`
+
+	// Stats Controls the inclusion in the returned dictionnary of a "stats" key, containing the selected properties distinct values counts.
+	Stats *InQueryStats `form:"stats,omitempty" json:"stats,omitempty"`
+
+	// Orderby Comma-separated list of properties to sort by. Prefix a property with - for descending order (e.g. orderby=nodename,-app).
+	Orderby *InQueryOrderby `form:"orderby,omitempty" json:"orderby,omitempty"`
+
+	// Groupby Comma-separated list of properties to group the result by (e.g. groupby=app,svcname).
+	Groupby *InQueryGroupby `form:"groupby,omitempty" json:"groupby,omitempty"`
+}
+
 // GetServicesParams defines parameters for GetServices.
 type GetServicesParams struct {
 	// Props A list of properties to include in each data dictionnary.
@@ -865,6 +1104,12 @@ type PostAppJSONRequestBody PostAppJSONBody
 // PostAuthNodeJSONRequestBody defines body for PostAuthNode for application/json ContentType.
 type PostAuthNodeJSONRequestBody PostAuthNodeJSONBody
 
+// PostDashboardSeverityRulesJSONRequestBody defines body for PostDashboardSeverityRules for application/json ContentType.
+type PostDashboardSeverityRulesJSONRequestBody = DashboardSeverityRuleInput
+
+// PutDashboardSeverityRuleJSONRequestBody defines body for PutDashboardSeverityRule for application/json ContentType.
+type PutDashboardSeverityRuleJSONRequestBody = DashboardSeverityRuleInput
+
 // PostNodeComplianceModulesetJSONRequestBody defines body for PostNodeComplianceModuleset for application/json ContentType.
 type PostNodeComplianceModulesetJSONRequestBody = PostNodeComplianceModulesetJSONBody
 
@@ -0,0 +1,55 @@
+package serverhandlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// dashboardSeverityRuleFromInput returns the valid dashboard severity rule
+// of the request body.
+func dashboardSeverityRuleFromInput(in server.DashboardSeverityRuleInput) (cdb.DashboardSeverityRule, error) {
+	r := cdb.DashboardSeverityRule{Severity: in.Severity}
+	if in.Priority != nil {
+		r.Priority = *in.Priority
+	}
+	if in.DashType != nil {
+		r.DashType = *in.DashType
+	}
+	if in.App != nil {
+		r.App = *in.App
+	}
+	if in.Env != nil {
+		r.Env = *in.Env
+	}
+	if in.ObjectPattern != nil {
+		r.ObjectPattern = *in.ObjectPattern
+	}
+	if r.Severity < 0 {
+		return r, fmt.Errorf("invalid severity %d", r.Severity)
+	}
+	if _, err := path.Match(r.ObjectPattern, ""); err != nil {
+		return r, fmt.Errorf("invalid object_pattern %s: %w", r.ObjectPattern, err)
+	}
+	return r, nil
+}
+
+// notifyDashboardSeverityRulesChange notifies the dashboard_severity_rules
+// table change, and asks the workers to reload the rules.
+func (a *Api) notifyDashboardSeverityRulesChange(ctx context.Context, log *slog.Logger, odb *cdb.DB, id int64) {
+	if err := odb.Session.NotifyTableChangeWithData(ctx, "dashboard_severity_rules", map[string]any{"id": id}); err != nil {
+		log.Error("cannot notify dashboard_severity_rules change", logkey.Error, err)
+	}
+	if a.Redis == nil {
+		return
+	}
+	if err := a.Redis.Publish(ctx, cachekeys.DashboardSeverityRulesP, id).Err(); err != nil {
+		log.Error("cannot publish dashboard severity rules change", logkey.Error, err)
+	}
+}
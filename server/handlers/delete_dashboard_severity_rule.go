@@ -0,0 +1,64 @@
+package serverhandlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// DeleteDashboardSeverityRule handles DELETE /dashboard/severity_rules/{rule_id}
+func (a *Api) DeleteDashboardSeverityRule(c echo.Context, ruleID server.InPathRuleId) error {
+	log := echolog.GetLogHandler(c, "DeleteDashboardSeverityRule")
+	ctx, cancel := context.WithTimeout(c.Request().Context(), a.SyncTimeout)
+	defer cancel()
+
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+
+	if !IsManager(c) {
+		return JSONProblemf(c, http.StatusForbidden, "Manager privilege required")
+	}
+
+	log.Info("called", "rule_id", ruleID)
+
+	odb := cdb.New(a.DB)
+	odb.CreateSession(a.Ev)
+
+	if found, err := odb.DashboardSeverityRule(ctx, ruleID); err != nil {
+		log.Error("cannot get dashboard severity rule", "rule_id", ruleID, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get dashboard severity rule")
+	} else if found == nil {
+		return JSONProblemf(c, http.StatusNotFound, "dashboard severity rule %d not found", ruleID)
+	}
+
+	if err := odb.DeleteDashboardSeverityRule(ctx, ruleID); err != nil {
+		log.Error("cannot delete dashboard severity rule", "rule_id", ruleID, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot delete dashboard severity rule")
+	}
+
+	userEmail, _ := c.Get(XUserEmail).(string)
+	if err := odb.Log(ctx, cdb.LogEntry{
+		Action: "dashboard_severity_rules.delete",
+		User:   userEmail,
+		Fmt:    "dashboard severity rule %(id)s deleted",
+		Dict: map[string]any{
+			"id": ruleID,
+		},
+		Level: "info",
+	}); err != nil {
+		log.Error("cannot write audit log", logkey.Error, err)
+	}
+
+	a.notifyDashboardSeverityRulesChange(ctx, log, odb, ruleID)
+	return c.JSON(http.StatusOK, map[string]string{
+		"info": fmt.Sprintf("dashboard severity rule %d deleted", ruleID),
+	})
+}
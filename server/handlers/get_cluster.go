@@ -0,0 +1,53 @@
+package serverhandlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// GetCluster handles GET /clusters/{cluster_id}
+func (a *Api) GetCluster(c echo.Context, clusterId string, params server.GetClusterParams) error {
+	query, err := buildListQueryParameters(params.Props, params.Limit, params.Offset, params.Meta, params.Stats, params.Orderby, params.Groupby, propsMapping["cluster"])
+	if err != nil {
+		return JSONProblem(c, http.StatusBadRequest, err.Error())
+	}
+
+	log := echolog.GetLogHandler(c, "GetCluster")
+	odb := a.getODB()
+	ctx := c.Request().Context()
+	groups := UserGroupsFromContext(c)
+	isManager := IsManager(c)
+
+	log.Info("called", "cluster_id", clusterId, "props", query.Props, "is_manager", isManager)
+
+	selectExprs, err := buildSelectClause(query.Props, propsMapping["cluster"])
+	if err != nil {
+		log.Error("cannot build select clause", logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot build select clause")
+	}
+
+	clusters, err := odb.GetCluster(ctx, clusterId, cdb.ListParams{
+		Groups:      groups,
+		IsManager:   isManager,
+		Limit:       query.Page.Limit,
+		Offset:      query.Page.Offset,
+		Props:       query.Props,
+		SelectExprs: selectExprs,
+		TypeHints:   buildTypeHints(query.Props, propsMapping["cluster"]),
+	})
+	if err != nil {
+		log.Error("cannot get cluster", "cluster_id", clusterId, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get cluster")
+	}
+	if len(clusters) == 0 {
+		return JSONProblemf(c, http.StatusNotFound, "cluster %s not found", clusterId)
+	}
+
+	return c.JSON(http.StatusOK, newListResponse(clusters, propsMapping["cluster"], query))
+}
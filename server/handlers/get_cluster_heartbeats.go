@@ -0,0 +1,185 @@
+package serverhandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// GetClusterHeartbeats handles GET /clusters/{cluster_id}/heartbeats
+func (a *Api) GetClusterHeartbeats(c echo.Context, clusterId string, params server.GetClusterHeartbeatsParams) error {
+	log := echolog.GetLogHandler(c, "GetClusterHeartbeats")
+	odb := a.getODB()
+	ctx := c.Request().Context()
+	isManager := IsManager(c)
+
+	log.Info("called", "cluster_id", clusterId)
+
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+
+	nodes, err := odb.NodesFromClusterID(ctx, clusterId)
+	if err != nil {
+		log.Error("cannot get cluster nodes", "cluster_id", clusterId, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get cluster nodes")
+	}
+	if len(nodes) == 0 {
+		return JSONProblemf(c, http.StatusNotFound, "cluster %s not found", clusterId)
+	}
+
+	if !isManager {
+		apps, err := odb.AppsForGroups(ctx, UserGroupsFromContext(c))
+		if err != nil {
+			log.Error("cannot get user apps", logkey.Error, err)
+			return JSONProblemf(c, http.StatusInternalServerError, "cannot check cluster responsibility")
+		}
+		if !clusterResponsible(nodes, apps) {
+			return JSONProblemf(c, http.StatusForbidden, "you are not responsible for a node of this cluster")
+		}
+	}
+
+	since := time.Now().Add(-24 * time.Hour)
+	if params.Since != nil {
+		since = *params.Since
+	}
+	heartbeats, err := odb.HBByClusterID(ctx, clusterId)
+	if err != nil {
+		log.Error("cannot get cluster heartbeats", "cluster_id", clusterId, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get cluster heartbeats")
+	}
+	logs, err := odb.HBLogByClusterID(ctx, clusterId, since)
+	if err != nil {
+		log.Error("cannot get cluster heartbeats history", "cluster_id", clusterId, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get cluster heartbeats history")
+	}
+	// the current periods follow the ended periods
+	lastLogs, err := odb.HBLogLastFromClusterID(ctx, clusterId)
+	if err != nil {
+		log.Error("cannot get cluster heartbeats current period", "cluster_id", clusterId, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get cluster heartbeats history")
+	}
+	logs = append(logs, lastLogs...)
+
+	return c.JSON(http.StatusOK, map[string]any{"data": newClusterHeartbeats(clusterId, nodes, heartbeats, logs)})
+}
+
+// clusterResponsible returns true if the app of one of the cluster nodes is
+// in apps.
+func clusterResponsible(nodes []*cdb.DBNode, apps []string) bool {
+	for _, node := range nodes {
+		for _, app := range apps {
+			if strings.EqualFold(app, node.App) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newClusterHeartbeats returns the cluster nodes heartbeats and the peer
+// matrix, with the history of each heartbeat from logs.
+func newClusterHeartbeats(clusterID string, nodes []*cdb.DBNode, heartbeats []cdb.DBHeartbeat, logs []*cdb.DBHeartbeatLog) server.ClusterHeartbeats {
+	nodenames := make(map[string]string)
+	for _, node := range nodes {
+		nodenames[node.NodeID] = node.Nodename
+	}
+	nodename := func(nodeID string) string {
+		if s, ok := nodenames[nodeID]; ok {
+			return s
+		}
+		return nodeID
+	}
+
+	history := make(map[string][]server.HeartbeatPeriod)
+	for _, l := range logs {
+		id := l.NodeID + "->" + l.PeerNodeID + ":" + l.Name
+		history[id] = append(history[id], server.HeartbeatPeriod{
+			State:   l.State,
+			Beating: heartbeatBeating(l.Beating),
+			Begin:   l.Begin,
+			End:     l.End,
+		})
+	}
+
+	result := server.ClusterHeartbeats{
+		ClusterId: clusterID,
+		Nodes:     make([]server.ClusterHeartbeatsNode, 0, len(nodes)),
+		Peers:     make([]server.ClusterHeartbeatsPeer, 0),
+	}
+	nodeIndex := make(map[string]int)
+	for _, node := range nodes {
+		nodeIndex[node.NodeID] = len(result.Nodes)
+		result.Nodes = append(result.Nodes, server.ClusterHeartbeatsNode{
+			NodeId:     node.NodeID,
+			Nodename:   node.Nodename,
+			Heartbeats: make([]server.Heartbeat, 0),
+		})
+	}
+	peerIndex := make(map[string]int)
+	for _, hb := range heartbeats {
+		h := server.Heartbeat{
+			Name:        hb.Name,
+			Driver:      hb.Driver,
+			Desc:        hb.Desc,
+			State:       hb.State,
+			Beating:     heartbeatBeating(hb.Beating),
+			LastBeating: hb.LastBeating,
+			Updated:     hb.Updated,
+			History:     history[hb.NodeID+"->"+hb.PeerNodeID+":"+hb.Name],
+		}
+		if h.History == nil {
+			h.History = make([]server.HeartbeatPeriod, 0)
+		}
+		if hb.PeerNodeID == "" {
+			// the node heartbeat state, without peer beating state
+			if i, ok := nodeIndex[hb.NodeID]; ok {
+				result.Nodes[i].Heartbeats = append(result.Nodes[i].Heartbeats, h)
+			}
+			continue
+		}
+		pair := hb.NodeID + "->" + hb.PeerNodeID
+		i, ok := peerIndex[pair]
+		if !ok {
+			i = len(result.Peers)
+			peerIndex[pair] = i
+			result.Peers = append(result.Peers, server.ClusterHeartbeatsPeer{
+				NodeId:       hb.NodeID,
+				Nodename:     nodename(hb.NodeID),
+				PeerNodeId:   hb.PeerNodeID,
+				PeerNodename: nodename(hb.PeerNodeID),
+				Beating:      server.HeartbeatBeatingNA,
+				Heartbeats:   make([]server.Heartbeat, 0),
+			})
+		}
+		peer := &result.Peers[i]
+		peer.Heartbeats = append(peer.Heartbeats, h)
+		switch {
+		case h.Beating == server.HeartbeatIsBeating:
+			peer.Beating = server.HeartbeatIsBeating
+		case h.Beating == server.HeartbeatNotBeating && peer.Beating == server.HeartbeatBeatingNA:
+			peer.Beating = server.HeartbeatNotBeating
+		}
+	}
+	return result
+}
+
+// heartbeatBeating returns the api beating state of the hbmon beating
+// value: 0: n/a, 1: beating, 2: not beating.
+func heartbeatBeating(beating int8) server.HeartbeatBeating {
+	switch beating {
+	case 1:
+		return server.HeartbeatIsBeating
+	case 2:
+		return server.HeartbeatNotBeating
+	default:
+		return server.HeartbeatBeatingNA
+	}
+}
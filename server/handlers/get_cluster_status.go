@@ -0,0 +1,57 @@
+package serverhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// GetClusterStatus handles GET /clusters/{cluster_id}/status
+func (a *Api) GetClusterStatus(c echo.Context, clusterId string) error {
+	log := echolog.GetLogHandler(c, "GetClusterStatus")
+	odb := a.getODB()
+	ctx := c.Request().Context()
+
+	log.Info("called", "cluster_id", clusterId)
+
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+
+	if !IsManager(c) {
+		nodes, err := odb.NodesFromClusterID(ctx, clusterId)
+		if err != nil {
+			log.Error("cannot get cluster nodes", "cluster_id", clusterId, logkey.Error, err)
+			return JSONProblemf(c, http.StatusInternalServerError, "cannot get cluster nodes")
+		}
+		apps, err := odb.AppsForGroups(ctx, UserGroupsFromContext(c))
+		if err != nil {
+			log.Error("cannot get user apps", logkey.Error, err)
+			return JSONProblemf(c, http.StatusInternalServerError, "cannot check cluster responsibility")
+		}
+		if !clusterResponsible(nodes, apps) {
+			return JSONProblemf(c, http.StatusForbidden, "you are not responsible for a node of this cluster")
+		}
+	}
+
+	data, updated, err := odb.ClusterData(ctx, clusterId)
+	if err != nil {
+		log.Error("cannot get cluster status", "cluster_id", clusterId, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get cluster status")
+	}
+	if len(data) == 0 {
+		return JSONProblemf(c, http.StatusNotFound, "no status for cluster %s", clusterId)
+	}
+	if !json.Valid(data) {
+		log.Warn("invalid cluster status document", "cluster_id", clusterId)
+		return JSONProblemf(c, http.StatusInternalServerError, "invalid cluster status document")
+	}
+	if !updated.IsZero() {
+		c.Response().Header().Set(echo.HeaderLastModified, updated.UTC().Format(http.TimeFormat))
+	}
+	return c.JSONBlob(http.StatusOK, data)
+}
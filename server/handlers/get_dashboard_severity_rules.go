@@ -0,0 +1,28 @@
+package serverhandlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// GetDashboardSeverityRules handles GET /dashboard/severity_rules
+func (a *Api) GetDashboardSeverityRules(c echo.Context) error {
+	log := echolog.GetLogHandler(c, "GetDashboardSeverityRules")
+	odb := a.getODB()
+
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+
+	log.Info("called")
+	rules, err := odb.DashboardSeverityRules(c.Request().Context())
+	if err != nil {
+		log.Error("cannot get dashboard severity rules", logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get dashboard severity rules")
+	}
+	return c.JSON(http.StatusOK, map[string]any{"data": rules})
+}
@@ -0,0 +1,60 @@
+package serverhandlers
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/git"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// GetNodeSysreportCommit handles GET /nodes/{node_id}/sysreport/{commit_id}
+func (a *Api) GetNodeSysreportCommit(c echo.Context, nodeId string, commitId string) error {
+	log := echolog.GetLogHandler(c, "GetNodeSysreportCommit")
+	odb := a.getODB()
+	ctx := c.Request().Context()
+
+	if !git.ValidCommitID(commitId) {
+		return JSONProblemf(c, http.StatusBadRequest, "invalid commit id %s", commitId)
+	}
+
+	node, err := odb.NodeByNodeIDOrNodename(ctx, nodeId)
+	if err != nil {
+		log.Error("cannot resolve node", logkey.NodeID, nodeId, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot resolve node")
+	}
+	if node == nil {
+		return JSONProblemf(c, http.StatusNotFound, "node %s not found", nodeId)
+	}
+
+	id, err := odb.SysreportCommitID(ctx, node.NodeID, commitId, UserGroupsFromContext(c), IsManager(c))
+	if errors.Is(err, cdb.ErrAmbiguousCommitID) {
+		return JSONProblemf(c, http.StatusBadRequest, "ambiguous commit id %s for node %s", commitId, nodeId)
+	} else if err != nil {
+		log.Error("cannot resolve sysreport commit", logkey.NodeID, node.NodeID, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot resolve sysreport commit")
+	}
+	if id == "" {
+		return JSONProblemf(c, http.StatusNotFound, "sysreport commit %s not found for node %s", commitId, nodeId)
+	}
+
+	repoDir := filepath.Join(viper.GetString("scheduler.directories.uploads"), "sysreport", node.NodeID)
+	diff, err := git.Show(ctx, repoDir, id)
+	if err != nil {
+		log.Error("cannot show sysreport commit", logkey.NodeID, node.NodeID, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot show sysreport commit %s", id)
+	}
+
+	return c.JSON(http.StatusOK, server.SysreportCommit{
+		NodeId:   node.NodeID,
+		CommitId: id,
+		Diff:     string(diff),
+	})
+}
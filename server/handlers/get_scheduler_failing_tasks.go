@@ -0,0 +1,59 @@
+package serverhandlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// GetSchedulerFailingTasks handles GET /scheduler/failing_tasks
+func (a *Api) GetSchedulerFailingTasks(c echo.Context, params server.GetSchedulerFailingTasksParams) error {
+	log := echolog.GetLogHandler(c, "GetSchedulerFailingTasks")
+	odb := a.getODB()
+	ctx := c.Request().Context()
+
+	log.Info("called")
+
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+	if !IsManager(c) {
+		return JSONProblemf(c, http.StatusForbidden, "Manager privilege required")
+	}
+
+	var minAge time.Duration
+	if params.MinAge != nil {
+		d, err := time.ParseDuration(*params.MinAge)
+		if err != nil || d < 0 {
+			return JSONProblemf(c, http.StatusBadRequest, "invalid min_age %q: expected a positive duration like 168h", *params.MinAge)
+		}
+		minAge = d
+	}
+
+	tasks, err := odb.SchedulerTasksFailing(ctx, minAge)
+	if err != nil {
+		log.Error("cannot get the failing scheduler tasks", logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get the failing scheduler tasks")
+	}
+
+	l := make([]server.SchedulerFailingTask, len(tasks))
+	for i, task := range tasks {
+		l[i] = server.SchedulerFailingTask{
+			TaskName:     task.TaskName,
+			FailingSince: task.FailingSince,
+			LastFailure:  task.LastFailure,
+			FailedRuns:   task.FailedRuns,
+			LastError:    task.LastError,
+		}
+		if !task.LastSuccess.IsZero() {
+			lastSuccess := task.LastSuccess
+			l[i].LastSuccess = &lastSuccess
+		}
+	}
+	return c.JSON(http.StatusOK, map[string]any{"data": l})
+}
@@ -0,0 +1,28 @@
+package serverhandlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+)
+
+// GetSchedulerRuns handles GET /scheduler/runs
+func (a *Api) GetSchedulerRuns(c echo.Context, params server.GetSchedulerRunsParams) error {
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+	if !IsManager(c) {
+		return JSONProblemf(c, http.StatusForbidden, "Manager privilege required")
+	}
+	odb := a.getODB()
+	return a.handleList(c, "GetSchedulerRuns", "scheduler_run", listEndpointParams{
+		props: params.Props, limit: params.Limit, offset: params.Offset,
+		meta: params.Meta, stats: params.Stats, orderby: params.Orderby, groupby: params.Groupby,
+	}, func(ctx context.Context, p cdb.ListParams) ([]map[string]any, error) {
+		return odb.GetSchedulerRuns(ctx, p)
+	})
+}
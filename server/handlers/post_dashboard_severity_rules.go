@@ -0,0 +1,65 @@
+package serverhandlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// PostDashboardSeverityRules handles POST /dashboard/severity_rules
+func (a *Api) PostDashboardSeverityRules(c echo.Context) error {
+	log := echolog.GetLogHandler(c, "PostDashboardSeverityRules")
+	ctx, cancel := context.WithTimeout(c.Request().Context(), a.SyncTimeout)
+	defer cancel()
+
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+
+	if !IsManager(c) {
+		return JSONProblemf(c, http.StatusForbidden, "Manager privilege required")
+	}
+
+	var body server.PostDashboardSeverityRulesJSONRequestBody
+	if err := c.Bind(&body); err != nil {
+		log.Error("invalid request body", logkey.Error, err)
+		return JSONProblem(c, http.StatusBadRequest, err.Error())
+	}
+	rule, err := dashboardSeverityRuleFromInput(body)
+	if err != nil {
+		return JSONProblem(c, http.StatusBadRequest, err.Error())
+	}
+
+	log.Info("called", "rule", rule)
+
+	odb := cdb.New(a.DB)
+	odb.CreateSession(a.Ev)
+
+	created, err := odb.InsertDashboardSeverityRule(ctx, rule)
+	if err != nil {
+		log.Error("cannot insert dashboard severity rule", logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot create dashboard severity rule")
+	}
+
+	userEmail, _ := c.Get(XUserEmail).(string)
+	if err := odb.Log(ctx, cdb.LogEntry{
+		Action: "dashboard_severity_rules.create",
+		User:   userEmail,
+		Fmt:    "dashboard severity rule %(id)s created",
+		Dict: map[string]any{
+			"id": created.ID,
+		},
+		Level: "info",
+	}); err != nil {
+		log.Error("cannot write audit log", logkey.Error, err)
+	}
+
+	a.notifyDashboardSeverityRulesChange(ctx, log, odb, created.ID)
+	return c.JSON(http.StatusOK, created)
+}
@@ -154,6 +154,50 @@ var propsMapping = map[string]propMapping{
 			"updated":               colStr(schema.NodesUpdated),
 		},
 	},
+	"cluster": {
+		Available: []string{
+			"id", "cluster_id", "cluster_name",
+			"nodes", "node_count", "objects", "object_count",
+			"hb_count", "hb_beating", "hb_not_beating",
+			"updated",
+		},
+		// updated is not a default prop, schema/upgrade.sql may not be applied yet
+		Default: []string{"id", "cluster_id", "cluster_name", "nodes", "object_count", "hb_count", "hb_not_beating"},
+		Props: map[string]propDef{
+			"id":           col(schema.ClustersID),
+			"cluster_id":   colStr(schema.ClustersClusterID),
+			"cluster_name": colStr(schema.ClustersClusterName),
+			"nodes": {
+				SQLExpr: "COALESCE((SELECT GROUP_CONCAT(n.nodename ORDER BY n.nodename SEPARATOR ',') FROM nodes n WHERE n.cluster_id = clusters.cluster_id), '')",
+				Kind:    "string",
+			},
+			"node_count": {
+				SQLExpr: "(SELECT COUNT(*) FROM nodes n WHERE n.cluster_id = clusters.cluster_id)",
+				Kind:    "int64",
+			},
+			"objects": {
+				SQLExpr: "COALESCE((SELECT GROUP_CONCAT(s.svcname ORDER BY s.svcname SEPARATOR ',') FROM services s WHERE s.cluster_id = clusters.cluster_id), '')",
+				Kind:    "string",
+			},
+			"object_count": {
+				SQLExpr: "(SELECT COUNT(*) FROM services s WHERE s.cluster_id = clusters.cluster_id)",
+				Kind:    "int64",
+			},
+			"hb_count": {
+				SQLExpr: "(SELECT COUNT(*) FROM hbmon h WHERE h.cluster_id = clusters.cluster_id AND h.peer_node_id != '')",
+				Kind:    "int64",
+			},
+			"hb_beating": {
+				SQLExpr: "(SELECT COUNT(*) FROM hbmon h WHERE h.cluster_id = clusters.cluster_id AND h.peer_node_id != '' AND h.beating = 1)",
+				Kind:    "int64",
+			},
+			"hb_not_beating": {
+				SQLExpr: "(SELECT COUNT(*) FROM hbmon h WHERE h.cluster_id = clusters.cluster_id AND h.peer_node_id != '' AND h.beating = 2)",
+				Kind:    "int64",
+			},
+			"updated": colStr(schema.ClustersUpdated),
+		},
+	},
 	"disk": {
 		Available: []string{
 			"disk_id", "disk_name", "disk_devid", "disk_vendor", "disk_model",
@@ -268,6 +312,20 @@ var propsMapping = map[string]propMapping{
 			"tag_attach_data": colStr(schema.NodeTagsTagAttachData),
 		},
 	},
+	"sysreport_log": {
+		Available: []string{"id", "node_id", "commit_id", "commit_date", "message", "changes", "updated"},
+		Default:   []string{"commit_id", "commit_date", "message", "changes"},
+		Blacklist: map[string]struct{}{"id": {}},
+		Props: map[string]propDef{
+			"id":          col(schema.SysreportLogID),
+			"node_id":     colStr(schema.SysreportLogNodeID),
+			"commit_id":   colStr(schema.SysreportLogCommitID),
+			"commit_date": colStr(schema.SysreportLogCommitDate),
+			"message":     colStr(schema.SysreportLogMessage),
+			"changes":     colInt(schema.SysreportLogChanges),
+			"updated":     colStr(schema.SysreportLogUpdated),
+		},
+	},
 	"svc_tag": {
 		Available: []string{"id", "created", "svc_id", "tag_id", "tag_attach_data"},
 		Blacklist: map[string]struct{}{"id": {}},
@@ -333,6 +391,7 @@ var propsMapping = map[string]propMapping{
 			"mon_sharestatus", "mon_syncstatus", "mon_appstatus", "mon_hbstatus",
 			"mon_frozen", "mon_frozen_at", "mon_encap_frozen_at",
 			"mon_vmname", "mon_vmtype", "mon_guestos", "mon_vcpus", "mon_vmem",
+			"mon_merge_rule",
 			"mon_updated", "mon_changed",
 		},
 		Default: []string{
@@ -364,6 +423,7 @@ var propsMapping = map[string]propMapping{
 			"mon_guestos":            colStr(schema.SvcmonMonGuestos),
 			"mon_vcpus":              colInt(schema.SvcmonMonVcpus),
 			"mon_vmem":               colInt(schema.SvcmonMonVmem),
+			"mon_merge_rule":         colStr(schema.SvcmonMonMergeRule),
 			"mon_updated":            colStr(schema.SvcmonMonUpdated),
 			"mon_changed":            colStr(schema.SvcmonMonChanged),
 		},
@@ -401,6 +461,26 @@ var propsMapping = map[string]propMapping{
 			"mon_appstatus":       colStr(schema.SvcmonLogMonAppstatus),
 		},
 	},
+	"scheduler_run": {
+		Available: []string{
+			"id", "task_name", "begin", "end", "duration_ms",
+			"status", "error", "rows_affected",
+		},
+		Props: map[string]propDef{
+			"id":        col(schema.Oc3SchedulerRunsID),
+			"task_name": colStr(schema.Oc3SchedulerRunsTaskName),
+			"begin":     colStr(schema.Oc3SchedulerRunsBegin),
+			"end":       colStr(schema.Oc3SchedulerRunsEnd),
+			"duration_ms": {
+				SQLExpr: "TIMESTAMPDIFF(MICROSECOND, oc3_scheduler_runs.begin, oc3_scheduler_runs.end) DIV 1000",
+				Kind:    "int64",
+			},
+			"status": colStr(schema.Oc3SchedulerRunsStatus),
+			"error":  colStr(schema.Oc3SchedulerRunsError),
+			// null when the task does not report the rows it changed
+			"rows_affected": col(schema.Oc3SchedulerRunsRowsAffected),
+		},
+	},
 	"moduleset": {
 		Available: []string{"id", "modset_name", "modset_author", "modset_updated"},
 	},
@@ -0,0 +1,72 @@
+package serverhandlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/server"
+	"github.com/opensvc/oc3/util/echolog"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// PutDashboardSeverityRule handles PUT /dashboard/severity_rules/{rule_id}
+func (a *Api) PutDashboardSeverityRule(c echo.Context, ruleID server.InPathRuleId) error {
+	log := echolog.GetLogHandler(c, "PutDashboardSeverityRule")
+	ctx, cancel := context.WithTimeout(c.Request().Context(), a.SyncTimeout)
+	defer cancel()
+
+	if !IsAuthByUser(c) {
+		return JSONProblemf(c, http.StatusUnauthorized, "user authentication required")
+	}
+
+	if !IsManager(c) {
+		return JSONProblemf(c, http.StatusForbidden, "Manager privilege required")
+	}
+
+	var body server.PutDashboardSeverityRuleJSONRequestBody
+	if err := c.Bind(&body); err != nil {
+		log.Error("invalid request body", logkey.Error, err)
+		return JSONProblem(c, http.StatusBadRequest, err.Error())
+	}
+	rule, err := dashboardSeverityRuleFromInput(body)
+	if err != nil {
+		return JSONProblem(c, http.StatusBadRequest, err.Error())
+	}
+	rule.ID = ruleID
+
+	log.Info("called", "rule", rule)
+
+	odb := cdb.New(a.DB)
+	odb.CreateSession(a.Ev)
+
+	if found, err := odb.DashboardSeverityRule(ctx, ruleID); err != nil {
+		log.Error("cannot get dashboard severity rule", "rule_id", ruleID, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot get dashboard severity rule")
+	} else if found == nil {
+		return JSONProblemf(c, http.StatusNotFound, "dashboard severity rule %d not found", ruleID)
+	}
+
+	if err := odb.UpdateDashboardSeverityRule(ctx, rule); err != nil {
+		log.Error("cannot update dashboard severity rule", "rule_id", ruleID, logkey.Error, err)
+		return JSONProblemf(c, http.StatusInternalServerError, "cannot update dashboard severity rule")
+	}
+
+	userEmail, _ := c.Get(XUserEmail).(string)
+	if err := odb.Log(ctx, cdb.LogEntry{
+		Action: "dashboard_severity_rules.change",
+		User:   userEmail,
+		Fmt:    "dashboard severity rule %(id)s changed",
+		Dict: map[string]any{
+			"id": ruleID,
+		},
+		Level: "info",
+	}); err != nil {
+		log.Error("cannot write audit log", logkey.Error, err)
+	}
+
+	a.notifyDashboardSeverityRulesChange(ctx, log, odb, ruleID)
+	return c.JSON(http.StatusOK, rule)
+}
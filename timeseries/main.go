@@ -2,10 +2,12 @@ package timeseries
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/go-graphite/go-whisper"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -13,6 +15,16 @@ var (
 	DailyRetentions   = whisper.MustParseRetentionDefs("1d:5y")
 )
 
+// MakeWSPFilename returns the path of a whisper file located in the stats
+// directory of scheduler.directories.uploads.
+func MakeWSPFilename(format string, args ...any) (string, error) {
+	directory := viper.GetString("scheduler.directories.uploads")
+	if directory == "" {
+		return "", fmt.Errorf("define scheduler.directories.uploads")
+	}
+	return filepath.Join(directory, "stats", fmt.Sprintf(format+".wsp", args...)), nil
+}
+
 func Update(wspFilename string, value float64, timestamp int, retentions whisper.Retentions, aggregationMethod whisper.AggregationMethod, xFilesFactor float32) error {
 	wsp, err := whisper.Open(wspFilename)
 	if errors.Is(err, os.ErrNotExist) {
@@ -1,23 +1,75 @@
 package git
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
-func Commit(repoDir string) error {
+type (
+	// Change is a file change of a commit
+	Change struct {
+		// Status is the git name-status letter: A, M, D, T...
+		Status string `json:"status"`
+
+		// Path is the changed file path relative to the repository
+		Path string `json:"path"`
+	}
+
+	// CommitInfo describes a commit created by Commit
+	CommitInfo struct {
+		ID      string    `json:"id"`
+		Date    time.Time `json:"date"`
+		Message string    `json:"message"`
+		Changes []Change  `json:"changes"`
+	}
+)
+
+var (
+	commitIDRegexp = regexp.MustCompile("^[0-9a-f]{4,40}$")
+)
+
+// ValidCommitID returns true if s looks like an abbreviated or full commit id
+func ValidCommitID(s string) bool {
+	return commitIDRegexp.MatchString(s)
+}
+
+// HasGitDirElem returns true if a slash or os separated path element of name
+// is ".git", in any case. Writing such a path in a repository work tree
+// would modify the repository itself, like its hooks or its config.
+func HasGitDirElem(name string) bool {
+	for _, elem := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '/' || r == filepath.Separator
+	}) {
+		if strings.EqualFold(strings.TrimSpace(elem), ".git") {
+			return true
+		}
+	}
+	return false
+}
+
+// Commit stages all the repoDir changes and commits them with a message
+// listing the changed files. The repository is initialized if needed.
+// It returns a nil CommitInfo when there is nothing to commit. The callers
+// serialize the commits of a repository: the index.lock of a concurrent or
+// interrupted git command fails the commit.
+func Commit(ctx context.Context, repoDir string) (*CommitInfo, error) {
 	gitDir := filepath.Join(repoDir, ".git")
 
 	if _, err := exec.LookPath("git"); err != nil {
-		return fmt.Errorf("git not found: %w", err)
+		return nil, fmt.Errorf("git not found: %w", err)
 	}
 
 	if _, err := os.Stat(repoDir); os.IsNotExist(err) {
-		return fmt.Errorf("dir does not exist: %s", repoDir)
+		return nil, fmt.Errorf("dir does not exist: %s", repoDir)
 	}
 
 	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
@@ -29,25 +81,113 @@ func Commit(repoDir string) error {
 			{"config", "user.name", "collector"},
 		}
 		for _, args := range cmds {
-			cmd := exec.Command("git", args...)
-			cmd.Dir = repoDir
-			if out, err := cmd.CombinedOutput(); err != nil {
-				return fmt.Errorf("git %v failed: %s: %w", args, out, err)
+			if _, err := run(ctx, repoDir, nil, args...); err != nil {
+				return nil, err
 			}
 		}
 	}
 
-	_ = os.Remove(filepath.Join(gitDir, "index.lock"))
+	if _, err := run(ctx, repoDir, nil, "add", "--all", "."); err != nil {
+		return nil, err
+	}
+
+	out, err := run(ctx, repoDir, nil, "diff", "--cached", "--name-status", "--no-renames")
+	if err != nil {
+		return nil, err
+	}
+	changes := parseNameStatus(out)
+	if len(changes) == 0 {
+		return nil, nil
+	}
 
-	cmd := exec.Command("git", "add", ".")
-	cmd.Dir = repoDir
-	if out, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git add failed: %s: %w", out, err)
+	message := commitMessage(changes)
+	if _, err := run(ctx, repoDir, strings.NewReader(message), "commit", "--quiet", "--file", "-"); err != nil {
+		return nil, err
 	}
 
-	cmd = exec.Command("git", "commit", "-m", "sysreport", "-a")
-	cmd.Dir = repoDir
-	_ = cmd.Run()
+	out, err = run(ctx, repoDir, nil, "log", "-1", "--format=%H %cI")
+	if err != nil {
+		return nil, err
+	}
+	id, date, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	info := &CommitInfo{ID: id, Message: message, Changes: changes}
+	if info.Date, err = time.Parse(time.RFC3339, date); err != nil {
+		return info, fmt.Errorf("parse commit date %s: %w", date, err)
+	}
+	return info, nil
+}
 
-	return nil
+// Head returns the HEAD commit of the repoDir repository, or nil if the
+// repository is not initialized or has no commit yet.
+func Head(ctx context.Context, repoDir string) (*CommitInfo, error) {
+	if _, err := os.Stat(filepath.Join(repoDir, ".git")); os.IsNotExist(err) {
+		return nil, nil
+	}
+	if _, err := run(ctx, repoDir, nil, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil, nil
+	}
+	out, err := run(ctx, repoDir, nil, "log", "-1", "--format=%H %cI%n%B")
+	if err != nil {
+		return nil, err
+	}
+	header, message, _ := strings.Cut(string(out), "\n")
+	id, date, _ := strings.Cut(header, " ")
+	out, err = run(ctx, repoDir, nil, "show", "--format=", "--name-status", "--no-renames", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	info := &CommitInfo{ID: id, Message: strings.TrimRight(message, "\n") + "\n", Changes: parseNameStatus(out)}
+	if info.Date, err = time.Parse(time.RFC3339, date); err != nil {
+		return info, fmt.Errorf("parse commit date %s: %w", date, err)
+	}
+	return info, nil
+}
+
+// Show returns the patch introduced by the commit id of the repoDir repository
+func Show(ctx context.Context, repoDir, id string) ([]byte, error) {
+	if !ValidCommitID(id) {
+		return nil, fmt.Errorf("invalid commit id: %s", id)
+	}
+	return run(ctx, repoDir, nil, "show", "--format=", "--patch", "--no-color", id)
+}
+
+// commitMessage returns the change line of a single change commit, or a
+// summary line followed by the list of changes.
+func commitMessage(changes []Change) string {
+	var b strings.Builder
+	if len(changes) == 1 {
+		return fmt.Sprintf("%s %s\n", changes[0].Status, changes[0].Path)
+	}
+	fmt.Fprintf(&b, "%d files changed\n\n", len(changes))
+	for _, change := range changes {
+		fmt.Fprintf(&b, "%s %s\n", change.Status, change.Path)
+	}
+	return b.String()
+}
+
+func parseNameStatus(b []byte) []Change {
+	var changes []Change
+	for _, line := range strings.Split(string(b), "\n") {
+		status, path, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		changes = append(changes, Change{Status: status, Path: path})
+	}
+	return changes
+}
+
+func run(ctx context.Context, dir string, stdin *strings.Reader, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s failed: %s: %w", args[0], strings.TrimSpace(stderr.String()), err)
+	}
+	return stdout.Bytes(), nil
 }
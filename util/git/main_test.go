@@ -0,0 +1,37 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHead(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	if head, err := Head(ctx, dir); err != nil || head != nil {
+		t.Fatalf("Head of a not initialized repository = %v, %v, expected nil, nil", head, err)
+	}
+	for _, name := range []string{"a", "b"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit, err := Commit(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := Head(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(head, commit) {
+		t.Errorf("Head = %+v, expected the last commit %+v", head, commit)
+	}
+}
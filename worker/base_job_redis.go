@@ -2,13 +2,29 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/opensvc/oc3/cachekeys"
 	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/feeder"
+)
+
+var (
+	// dropPendingScript removes the ARGV[1] field of the KEYS[1] pending
+	// hash. It returns the removed value, or "" if the field does not exist,
+	// and the redis server unix time in milliseconds.
+	dropPendingScript = redis.NewScript(`
+local v = redis.call('HGET', KEYS[1], ARGV[1])
+redis.call('HDEL', KEYS[1], ARGV[1])
+local t = redis.call('TIME')
+return {v or '', t[1] * 1000 + math.floor(t[2] / 1000)}
+`)
 )
 
 type (
@@ -22,6 +38,11 @@ type (
 		// cachePendingIDX is the cache id used by BaseJob.dropPending:
 		// HDEL <cachePendingH> <cachePendingIDX>
 		cachePendingIDX string
+
+		// cacheResultP is the channel where publishResult publishes the
+		// job result for the feeder handlers running in synchronous mode.
+		// No result is published when empty.
+		cacheResultP string
 	}
 )
 
@@ -66,9 +87,44 @@ func (d *JobRedis) populateFeedObjectConfigForClusterIDH(ctx context.Context, cl
 	return nil, nil
 }
 
+// dropPending removes the job index from the pending hash, so the feeder
+// can queue a new job for the index. The pending hash value is the enqueue
+// time set by the feeder, used to observe the queue latency.
 func (j *JobRedis) dropPending(ctx context.Context) error {
-	if err := j.redis.HDel(ctx, j.cachePendingH, j.cachePendingIDX).Err(); err != nil {
-		return fmt.Errorf("dropPending: HDEL %s %s: %w", j.cachePendingH, j.cachePendingIDX, err)
+	result, err := dropPendingScript.Run(ctx, j.redis, []string{j.cachePendingH}, j.cachePendingIDX).Slice()
+	if err != nil {
+		return fmt.Errorf("dropPending: %s %s: %w", j.cachePendingH, j.cachePendingIDX, err)
+	}
+	if len(result) != 2 {
+		return nil
+	}
+	enqueuedAt, _ := result[0].(string)
+	now, _ := result[1].(int64)
+	if enqueuedAtMs, err := strconv.ParseInt(enqueuedAt, 10, 64); err == nil && now >= enqueuedAtMs {
+		feedQueueLatency.
+			With(prometheus.Labels{"queue": pendingHashQueueName(j.cachePendingH)}).
+			Observe(float64(now-enqueuedAtMs) / 1000)
+	}
+	return nil
+}
+
+// publishResult publishes the job result, identified by cachePendingIDX, to
+// the cacheResultP channel.
+func (j *JobRedis) publishResult(ctx context.Context, jobErr error) error {
+	if j.cacheResultP == "" {
+		return nil
+	}
+	result := feeder.JobResult{ID: j.cachePendingIDX, Status: jobStatusOk}
+	if jobErr != nil {
+		result.Status = jobStatusFailed
+		result.Error = jobErr.Error()
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("publishResult: %w", err)
+	}
+	if err := j.redis.Publish(ctx, j.cacheResultP, b).Err(); err != nil {
+		return fmt.Errorf("publishResult: PUBLISH %s: %w", j.cacheResultP, err)
 	}
 	return nil
 }
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// capture archives the payload of the job of the queue entry to
+// <w.CaptureDir>/<node id>/<job type>-<time>-<escaped entry>.json if the
+// entry node id is one of w.CaptureNodeIDs. The archived payloads can be
+// replayed with `oc3 worker replay`.
+func (w *Worker) capture(ctx context.Context, jt registeredJobType, entry string) error {
+	if w.CaptureDir == "" || jt.dataH() == "" {
+		return nil
+	}
+	nodeID := jt.nodeID(entry)
+	if nodeID == "" || !slices.Contains(w.CaptureNodeIDs, nodeID) {
+		return nil
+	}
+	b, err := w.Redis.HGet(ctx, jt.dataH(), entry).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("HGET %s %s: %w", jt.dataH(), entry, err)
+	}
+	dir := filepath.Join(w.CaptureDir, nodeID)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s-%s.json", jt.name(), time.Now().UTC().Format("20060102T150405.000000000"), url.PathEscape(entry))
+	return os.WriteFile(filepath.Join(dir, name), b, 0o640)
+}
@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type (
+	// daemonDataEvents is the dataProvider of the cluster daemon status
+	// maintained by the feeder from the daemon events. The fields of the
+	// cachekeys.FeedDaemonEventsH hash are nested back to the v3 daemon
+	// status data.cluster document.
+	daemonDataEvents struct {
+		*daemonDataV3
+	}
+)
+
+// newDaemonDataEvents returns the dataProvider of the daemon events hash
+// fields, and the json encoded daemon status document.
+func newDaemonDataEvents(fields map[string]string) (*daemonDataEvents, []byte, error) {
+	cluster := map[string]any{
+		"node":   make(map[string]any),
+		"object": make(map[string]any),
+	}
+	for field, s := range fields {
+		keys := strings.Fields(field)
+		if len(keys) == 0 {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, nil, fmt.Errorf("unexpected value of field '%s': %w", field, err)
+		}
+		m := cluster
+		for _, k := range keys[:len(keys)-1] {
+			sub, ok := m[k].(map[string]any)
+			if !ok {
+				sub = make(map[string]any)
+				m[k] = sub
+			}
+			m = sub
+		}
+		m[keys[len(keys)-1]] = v
+	}
+	b, err := json.Marshal(map[string]any{"data": map[string]any{"cluster": cluster}})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &daemonDataEvents{daemonDataV3: &daemonDataV3{cluster: cluster}}, b, nil
+}
+
+// daemonEventsFields returns the daemon events hash fields of the v3 daemon
+// status data.cluster document.
+func daemonEventsFields(cluster map[string]any) (map[string]any, error) {
+	fields := make(map[string]any)
+	set := func(v any, keys ...string) error {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(keys, "."), err)
+		}
+		fields[strings.Join(keys, " ")] = string(b)
+		return nil
+	}
+	if v, ok := cluster["config"]; ok {
+		if err := set(v, "config"); err != nil {
+			return nil, err
+		}
+	}
+	nodes, _ := cluster["node"].(map[string]any)
+	for nodename, i := range nodes {
+		node, ok := i.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, k := range []string{"status", "monitor"} {
+			if v, ok := node[k]; ok {
+				if err := set(v, "node", nodename, k); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if v, ok := mapTo(node, "daemon", "heartbeat"); ok {
+			if err := set(v, "node", nodename, "daemon", "heartbeat"); err != nil {
+				return nil, err
+			}
+		}
+		instances, _ := node["instance"].(map[string]any)
+		for path, i := range instances {
+			instance, ok := i.(map[string]any)
+			if !ok {
+				continue
+			}
+			for _, k := range []string{"status", "monitor", "config"} {
+				if v, ok := instance[k]; ok {
+					if err := set(v, "node", nodename, "instance", path, k); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	objects, _ := cluster["object"].(map[string]any)
+	for path, v := range objects {
+		if err := set(v, "object", path); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
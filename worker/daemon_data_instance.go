@@ -109,35 +109,34 @@ func (i *instanceData) Container(id string) *instanceData {
 	if containerType := strings.SplitN(mapToS(i.resources, "", id, "type"), ".", -1); len(containerType) > 1 {
 		dbI.MonVmType = containerType[1]
 	}
-	mergeM := hypervisorContainerMergeMap
-	if encapAvail, ok := encap["avail"].(string); ok {
-		dbI.MonAvailStatus = mergeM[i.MonAvailStatus+","+encapAvail]
-	} else {
-		dbI.MonAvailStatus = mergeM[i.MonAvailStatus+",n/a"]
-	}
-	if encapOverall, ok := encap["overall"].(string); ok {
-		dbI.MonOverallStatus = mergeM[i.MonOverallStatus+","+encapOverall]
-	} else {
-		dbI.MonOverallStatus = mergeM[i.MonOverallStatus+",n/a"]
+	mergeGroup := func(hypervisor, encap string) string {
+		status, _ := mergeStatus(statusKindAvail, hypervisor, encap)
+		return status
 	}
+	var availRule, overallRule string
+	encapAvail, _ := encap["avail"].(string)
+	dbI.MonAvailStatus, availRule = mergeStatus(statusKindAvail, i.MonAvailStatus, encapAvail)
+	encapOverall, _ := encap["overall"].(string)
+	dbI.MonOverallStatus, overallRule = mergeStatus(statusKindOverall, i.MonOverallStatus, encapOverall)
+	dbI.MonMergeRule = availRule + "; " + overallRule
 
 	if statusGroup, ok := encap["status_group"].(map[string]string); ok {
-		dbI.MonIpStatus = mergeM[i.MonIpStatus+","+statusGroup["ip"]]
-		dbI.MonDiskStatus = mergeM[i.MonDiskStatus+","+statusGroup["disk"]]
-		dbI.MonFsStatus = mergeM[i.MonFsStatus+","+statusGroup["fs"]]
-		dbI.MonShareStatus = mergeM[i.MonShareStatus+","+statusGroup["share"]]
-		dbI.MonContainerStatus = mergeM[i.MonContainerStatus+","+statusGroup["container"]]
-		dbI.MonAppStatus = mergeM[i.MonAppStatus+","+statusGroup["app"]]
-		dbI.MonSyncStatus = mergeM[i.MonSyncStatus+","+statusGroup["sync"]]
+		dbI.MonIpStatus = mergeGroup(i.MonIpStatus, statusGroup["ip"])
+		dbI.MonDiskStatus = mergeGroup(i.MonDiskStatus, statusGroup["disk"])
+		dbI.MonFsStatus = mergeGroup(i.MonFsStatus, statusGroup["fs"])
+		dbI.MonShareStatus = mergeGroup(i.MonShareStatus, statusGroup["share"])
+		dbI.MonContainerStatus = mergeGroup(i.MonContainerStatus, statusGroup["container"])
+		dbI.MonAppStatus = mergeGroup(i.MonAppStatus, statusGroup["app"])
+		dbI.MonSyncStatus = mergeGroup(i.MonSyncStatus, statusGroup["sync"])
 	} else {
 		// unexpected status_group map, ignore all encap status_group
-		dbI.MonIpStatus = mergeM[i.MonIpStatus+","]
-		dbI.MonDiskStatus = mergeM[i.MonDiskStatus+","]
-		dbI.MonFsStatus = mergeM[i.MonFsStatus+","]
-		dbI.MonShareStatus = mergeM[i.MonShareStatus+","]
-		dbI.MonContainerStatus = mergeM[i.MonContainerStatus+","]
-		dbI.MonAppStatus = mergeM[i.MonAppStatus+","]
-		dbI.MonSyncStatus = mergeM[i.MonSyncStatus+","]
+		dbI.MonIpStatus = mergeGroup(i.MonIpStatus, "")
+		dbI.MonDiskStatus = mergeGroup(i.MonDiskStatus, "")
+		dbI.MonFsStatus = mergeGroup(i.MonFsStatus, "")
+		dbI.MonShareStatus = mergeGroup(i.MonShareStatus, "")
+		dbI.MonContainerStatus = mergeGroup(i.MonContainerStatus, "")
+		dbI.MonAppStatus = mergeGroup(i.MonAppStatus, "")
+		dbI.MonSyncStatus = mergeGroup(i.MonSyncStatus, "")
 	}
 
 	// frozen value merge rules:
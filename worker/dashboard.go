@@ -1,6 +1,8 @@
 package worker
 
 import (
+	"path"
+	"sync/atomic"
 	"time"
 
 	"github.com/opensvc/oc3/cdb"
@@ -29,8 +31,51 @@ var (
 		dashObjObjectPlacement:   map[string]int{"DEFAULT": 1},
 		dashObjObjectUnavailable: map[string]int{"DEFAULT": 3, "PRD": 4},
 	}
+
+	// dashTypeName is the dash_type of the dashboard types, matched by the
+	// severity rules.
+	dashTypeName = map[int]string{
+		dashObjObjectDegraded:    "service available but degraded",
+		dashObjObjectFlexError:   "flex error",
+		dashObjObjectPlacement:   "service placement",
+		dashObjObjectUnavailable: "service unavailable",
+	}
+
+	// severityRules are the dashboard severity rules by decreasing
+	// priority, replaced on rules reload.
+	severityRules atomic.Pointer[[]cdb.DashboardSeverityRule]
 )
 
+// severityFor returns the severity of the first severity rule matching the
+// dashboard type and the object, or the default severity of the dashboard
+// type for the object env.
+func severityFor(dashType int, obj *cdb.DBObject) int {
+	if rules := severityRules.Load(); rules != nil {
+		for _, rule := range *rules {
+			if matchSeverityRule(rule, dashTypeName[dashType], obj) {
+				return rule.Severity
+			}
+		}
+	}
+	return severityFromEnv(dashType, obj.Env)
+}
+
+func matchSeverityRule(rule cdb.DashboardSeverityRule, dashType string, obj *cdb.DBObject) bool {
+	switch {
+	case rule.DashType != "" && rule.DashType != dashType:
+		return false
+	case rule.App != "" && rule.App != obj.App:
+		return false
+	case rule.Env != "" && rule.Env != obj.Env:
+		return false
+	case rule.ObjectPattern != "":
+		matched, _ := path.Match(rule.ObjectPattern, obj.Svcname)
+		return matched
+	default:
+		return true
+	}
+}
+
 func severityFromEnv(dashType int, objEnv string) int {
 	severityForType := severity[dashType]
 	if severityForType == nil {
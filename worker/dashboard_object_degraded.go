@@ -25,5 +25,5 @@ func (d *DashboardObjectDegraded) Dict() string {
 }
 
 func (d *DashboardObjectDegraded) Severity() int {
-	return severityFromEnv(dashObjObjectDegraded, d.obj.Env)
+	return severityFor(dashObjObjectDegraded, d.obj)
 }
@@ -25,5 +25,5 @@ func (d *DashboardObjectPlacement) Dict() string {
 }
 
 func (d *DashboardObjectPlacement) Severity() int {
-	return severityFromEnv(dashObjObjectPlacement, d.obj.Env)
+	return severityFor(dashObjObjectPlacement, d.obj)
 }
@@ -25,5 +25,5 @@ func (d *DashboardObjectUnavailable) Dict() string {
 }
 
 func (d *DashboardObjectUnavailable) Severity() int {
-	return severityFromEnv(dashObjObjectUnavailable, d.obj.Env)
+	return severityFor(dashObjObjectUnavailable, d.obj)
 }
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/cdb"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// watchSeverityRules loads the dashboard severity rules, then reloads them
+// when the server publishes a rules change, and every interval to catch
+// the changes made directly in the database.
+func (w *Worker) watchSeverityRules(ctx context.Context, interval time.Duration) {
+	pubsub := w.Redis.Subscribe(ctx, cachekeys.DashboardSeverityRulesP)
+	defer func() { _ = pubsub.Close() }()
+	changes := pubsub.Channel()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.loadSeverityRules(ctx); err != nil {
+			slog.Warn("load dashboard severity rules", logkey.Error, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-changes:
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadSeverityRules replaces the dashboard severity rules by the rules of
+// the dashboard_severity_rules table and the w.SeverityRules configured
+// rules. On equal priority, the table rules are applied first.
+func (w *Worker) loadSeverityRules(ctx context.Context) error {
+	l, err := cdb.New(w.DB).DashboardSeverityRules(ctx)
+	if err != nil {
+		return err
+	}
+	rules := append(l, w.SeverityRules...)
+	slices.SortStableFunc(rules, func(a, b cdb.DashboardSeverityRule) int {
+		return b.Priority - a.Priority
+	})
+	if old := severityRules.Swap(&rules); old == nil || !slices.Equal(*old, rules) {
+		slog.Info(fmt.Sprintf("loaded %d dashboard severity rules", len(rules)))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package worker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-graphite/go-whisper"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/timeseries"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+type (
+	jobFeedChecks struct {
+		JobBase
+		JobRedis
+		JobDB
+
+		nodeID string
+
+		// vars is the list of checks_live column names of the posted checks
+		vars []string
+
+		// vals is the list of posted checks, each value is ordered as vars
+		vals [][]any
+	}
+)
+
+const (
+	// checksInsertBatchSize is the maximum number of rows per checks_live insert
+	checksInsertBatchSize = 100
+)
+
+func init() {
+	Register(JobType[NodeIndex]{
+		Name:     jtChecks,
+		Queue:    "checks",
+		PendingH: cachekeys.FeedChecksPendingH,
+		DataH:    cachekeys.FeedChecksH,
+		Codec:    nodeCodec{},
+		New:      func(index NodeIndex) JobRunner { return newChecks(string(index)) },
+	})
+}
+
+func newChecks(nodeID string) *jobFeedChecks {
+	return &jobFeedChecks{
+		JobBase: JobBase{
+			name:   jtChecks,
+			detail: "nodeID: " + nodeID,
+			logger: slog.With(logkey.NodeID, nodeID, logkey.JobName, jtChecks),
+		},
+		JobRedis: JobRedis{
+			cachePendingH:   cachekeys.FeedChecksPendingH,
+			cachePendingIDX: nodeID,
+		},
+		nodeID: nodeID,
+	}
+}
+
+func (d *jobFeedChecks) Operations() []operation {
+	hasVals := func() bool { return len(d.vals) > 0 }
+	return []operation{
+		{name: "dropPending", do: d.dropPending},
+		{name: "getData", do: d.getData, blocking: true},
+		{name: "dbNow", do: d.dbNow, blocking: true},
+		{name: "dbPurgeChecksLive", do: d.dbPurgeChecksLive, blocking: true},
+		{name: "dbInsertChecksLive", do: d.dbInsertChecksLive, condition: hasVals},
+		{name: "updateTimeseries", do: d.updateTimeseries, condition: hasVals},
+		{name: "pushFromTableChanges", do: d.pushFromTableChanges},
+		{name: "pushChecksChange", do: d.pushChecksChange},
+	}
+}
+
+// getData populates d.vars and d.vals from the FeedChecksH <nodeID> value: [<vars>, <vals>]
+func (d *jobFeedChecks) getData(ctx context.Context) error {
+	var data []json.RawMessage
+	if b, err := d.redis.HGet(ctx, cachekeys.FeedChecksH, d.nodeID).Bytes(); err != nil {
+		return fmt.Errorf("getData: HGET %s %s: %w", cachekeys.FeedChecksH, d.nodeID, err)
+	} else if err := json.Unmarshal(b, &data); err != nil {
+		return fmt.Errorf("getData: unexpected data from %s %s: %w", cachekeys.FeedChecksH, d.nodeID, err)
+	}
+	if len(data) != 2 {
+		return fmt.Errorf("getData: unexpected data length: %d", len(data))
+	}
+	if err := json.Unmarshal(data[0], &d.vars); err != nil {
+		return fmt.Errorf("getData: unmarshal vars: %w", err)
+	}
+	if err := json.Unmarshal(data[1], &d.vals); err != nil {
+		return fmt.Errorf("getData: unmarshal vals: %w", err)
+	}
+	return nil
+}
+
+func (d *jobFeedChecks) dbPurgeChecksLive(ctx context.Context) error {
+	return d.oDb.PurgeChecksLive(ctx, d.nodeID)
+}
+
+// dbInsertChecksLive inserts the posted checks with chk_updated and node_id
+// forced to the job values. A failing batch doesn't prevent the insertion of
+// the other batches.
+func (d *jobFeedChecks) dbInsertChecksLive(ctx context.Context) error {
+	var errs error
+	vars := d.vars
+	idxUpdated, idxNodeID := -1, -1
+	for i, v := range vars {
+		switch v {
+		case "chk_updated":
+			idxUpdated = i
+		case "node_id":
+			idxNodeID = i
+		}
+	}
+	if idxUpdated < 0 {
+		idxUpdated = len(vars)
+		vars = append(vars, "chk_updated")
+	}
+	if idxNodeID < 0 {
+		idxNodeID = len(vars)
+		vars = append(vars, "node_id")
+	}
+	vals := make([][]any, 0, len(d.vals))
+	for _, row := range d.vals {
+		for len(row) < len(vars) {
+			row = append(row, nil)
+		}
+		row[idxUpdated] = d.now
+		row[idxNodeID] = d.nodeID
+		vals = append(vals, row)
+	}
+	for i := 0; i < len(vals); i += checksInsertBatchSize {
+		end := min(i+checksInsertBatchSize, len(vals))
+		if err := d.oDb.InsertChecksLive(ctx, vars, vals[i:end]); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("batch %d-%d: %w", i, end, err))
+			continue
+		}
+		d.oDb.SetChange("checks_live")
+	}
+	return errs
+}
+
+// updateTimeseries updates the whisper file of each node live checks
+func (d *jobFeedChecks) updateTimeseries(ctx context.Context) error {
+	var errs error
+	checks, err := d.oDb.GetChecksLiveForNode(ctx, d.nodeID)
+	if err != nil {
+		return fmt.Errorf("updateTimeseries: %w", err)
+	}
+	timestamp := int(d.now.Unix())
+	for _, check := range checks {
+		instance := check.ChkInstance
+		if instance != "" {
+			instance = base64.RawURLEncoding.EncodeToString([]byte(instance))
+		}
+		path, err := timeseries.MakeWSPFilename("nodes/%s/checks/%s:%s:%s", d.nodeID, check.SvcID, check.ChkType, instance)
+		if err != nil {
+			return fmt.Errorf("updateTimeseries: %w", err)
+		}
+		if err := timeseries.Update(path, check.ChkValue, timestamp, timeseries.DefaultRetentions, whisper.Average, 0.5); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("update %s: %w", path, err))
+		}
+	}
+	return errs
+}
+
+// pushChecksChange publishes the checks_change event used to refresh the node
+// checks dashboard alerts.
+func (d *jobFeedChecks) pushChecksChange(ctx context.Context) error {
+	return d.oDb.Session.NotifyTableChangeWithData(ctx, "checks", map[string]any{"node_id": d.nodeID})
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/feeder"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+func init() {
+	Register(JobType[NodeClusterIndex]{
+		Name:     jtDaemonEvents,
+		Queue:    "daemon_events",
+		PendingH: cachekeys.FeedDaemonEventsPendingH,
+		Codec:    nodeClusterCodec{},
+		New:      func(index NodeClusterIndex) JobRunner { return newDaemonEvents(index) },
+	})
+}
+
+// newDaemonEvents returns the daemon status job applying the cluster daemon
+// status maintained by the feeder from the daemon events posted by the node.
+func newDaemonEvents(index NodeClusterIndex) *jobFeedDaemonStatus {
+	d := newDaemonStatus(index.NodeID)
+	d.name = jtDaemonEvents
+	d.detail = "nodeID: " + index.NodeID + " clusterID: " + index.ClusterID
+	d.logger = slog.With(logkey.NodeID, index.NodeID, logkey.ClusterID, index.ClusterID, logkey.JobName, jtDaemonEvents)
+	d.cachePendingH = cachekeys.FeedDaemonEventsPendingH
+	d.cachePendingIDX = nodeClusterCodec{}.Encode(index)
+	d.cacheResultP = cachekeys.FeedDaemonEventsP
+	d.eventsClusterID = index.ClusterID
+	return d
+}
+
+// getEventsData loads the cluster daemon status maintained from the daemon
+// events.
+func (d *jobFeedDaemonStatus) getEventsData(ctx context.Context) error {
+	key := cachekeys.FeedDaemonEventsH + d.eventsClusterID
+	fields, err := d.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("getData: HGETALL %s: %w", key, err)
+	} else if len(fields) == 0 {
+		return fmt.Errorf("getData: no cluster daemon status in %s", key)
+	}
+	data, b, err := newDaemonDataEvents(fields)
+	if err != nil {
+		return fmt.Errorf("getData: unexpected data from %s: %w", key, err)
+	}
+	d.data = data
+	d.rawData = b
+	return nil
+}
+
+// cacheDaemonEventsScript merges the v3 daemon status fields of a node to
+// the KEYS[1] cluster daemon status hash. ARGV[1] is the prefix of the
+// fields owned by the node, "node <nodename> ", and ARGV[2] the number of
+// owned field and value pairs that follow. The owned fields are set, and the
+// other owned fields of the hash are removed. The KEYS[2] set indexes the
+// fields of the node, so only the node fields are walked. The hash is walked
+// only once to seed the missing index.
+// The remaining field, value and key index triples, the other nodes and
+// cluster wide fields, are only set if missing, so the more recent values
+// applied from the daemon events are not rolled back. The key index is the
+// KEYS index of the node fields index to add the field to, 0 for a cluster
+// wide field.
+var cacheDaemonEventsScript = redis.NewScript(`
+local prefix = ARGV[1]
+local n = tonumber(ARGV[2])
+local owned = {}
+for i = 3, 2 + 2 * n, 2 do
+	owned[ARGV[i]] = true
+	redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+local previous = {}
+if redis.call('EXISTS', KEYS[2]) == 1 then
+	previous = redis.call('SMEMBERS', KEYS[2])
+else
+	for _, field in ipairs(redis.call('HKEYS', KEYS[1])) do
+		if string.sub(field, 1, #prefix) == prefix then
+			table.insert(previous, field)
+		end
+	end
+end
+for _, field in ipairs(previous) do
+	if not owned[field] then
+		redis.call('HDEL', KEYS[1], field)
+	end
+end
+redis.call('DEL', KEYS[2])
+for i = 3, 2 + 2 * n, 2 do
+	redis.call('SADD', KEYS[2], ARGV[i])
+end
+for i = 3 + 2 * n, #ARGV, 3 do
+	redis.call('HSETNX', KEYS[1], ARGV[i], ARGV[i + 1])
+	local k = tonumber(ARGV[i + 2])
+	if k > 0 then
+		redis.call('SADD', KEYS[k], ARGV[i])
+	end
+end
+return 0
+`)
+
+// cacheDaemonEvents merges the v3 daemon status to the cluster daemon status
+// maintained from the daemon events, so the next daemon events of the
+// cluster apply to an up to date cluster daemon status. Only the fields of
+// the posting node are replaced, the fields of the other nodes may already
+// be more recent than their copy in the posting node daemon status.
+func (d *jobFeedDaemonStatus) cacheDaemonEvents(ctx context.Context) error {
+	v3, ok := d.data.(*daemonDataV3)
+	if !ok {
+		return nil
+	}
+	fields, err := daemonEventsFields(v3.cluster)
+	if err != nil {
+		return fmt.Errorf("cacheDaemonEvents: %w", err)
+	}
+	key := cachekeys.FeedDaemonEventsH + d.clusterID
+	prefix := "node " + d.callerNode.Nodename + " "
+	keys := []string{key, cachekeys.FeedDaemonEventsNodeS + d.clusterID + ":" + d.callerNode.Nodename}
+	keyIndex := make(map[string]int)
+	var owned, others []any
+	for field, value := range fields {
+		if strings.HasPrefix(field, prefix) {
+			owned = append(owned, field, value)
+			continue
+		}
+		var k int
+		if nodeKey, ok := feeder.DaemonEventsNodeKey(d.clusterID, field); ok {
+			if k, ok = keyIndex[nodeKey]; !ok {
+				keys = append(keys, nodeKey)
+				k = len(keys)
+				keyIndex[nodeKey] = k
+			}
+		}
+		others = append(others, field, value, k)
+	}
+	args := append([]any{prefix, len(owned) / 2}, owned...)
+	args = append(args, others...)
+	if err := cacheDaemonEventsScript.Run(ctx, d.redis, keys, args...).Err(); err != nil {
+		return fmt.Errorf("cacheDaemonEvents: merge %s: %w", key, err)
+	}
+	return nil
+}
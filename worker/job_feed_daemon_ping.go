@@ -33,6 +33,17 @@ type (
 	}
 )
 
+func init() {
+	Register(JobType[NodeIndex]{
+		Name:     jtDaemonPing,
+		Queue:    "daemon_ping",
+		PendingH: cachekeys.FeedDaemonPingPendingH,
+		DataH:    cachekeys.FeedDaemonPingH,
+		Codec:    nodeCodec{},
+		New:      func(index NodeIndex) JobRunner { return newDaemonPing(string(index)) },
+	})
+}
+
 func newDaemonPing(nodeID string) *jobFeedDaemonPing {
 	return &jobFeedDaemonPing{
 		JobBase: JobBase{
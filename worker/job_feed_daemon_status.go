@@ -17,6 +17,18 @@ import (
 	"github.com/opensvc/oc3/util/logkey"
 )
 
+var (
+	// takeChangesScript moves the KEYS[1] changes set members to the KEYS[2]
+	// taken set, and returns the taken set members. The members of the taken
+	// set are the changes taken by the previous jobs not yet committed, either
+	// failed or running.
+	takeChangesScript = redis.NewScript(`
+redis.call('SUNIONSTORE', KEYS[2], KEYS[1], KEYS[2])
+redis.call('DEL', KEYS[1])
+return redis.call('SMEMBERS', KEYS[2])
+`)
+)
+
 type (
 	dataLister interface {
 		objectNames() ([]string, error)
@@ -62,6 +74,11 @@ type (
 		nodeEnv     string
 		callerNode  *cdb.DBNode
 
+		// eventsClusterID is the cluster id of the daemon events jobs, that
+		// read the cluster daemon status maintained from the daemon events
+		// instead of the node daemon status.
+		eventsClusterID string
+
 		changes    map[string]struct{}
 		rawChanges string
 		rawData    []byte
@@ -88,6 +105,17 @@ type (
 	}
 )
 
+func init() {
+	Register(JobType[NodeIndex]{
+		Name:     jtDaemonStatus,
+		Queue:    "daemon_status",
+		PendingH: cachekeys.FeedDaemonStatusPendingH,
+		DataH:    cachekeys.FeedDaemonStatusH,
+		Codec:    nodeCodec{},
+		New:      func(index NodeIndex) JobRunner { return newDaemonStatus(string(index)) },
+	})
+}
+
 func newDaemonStatus(nodeID string) *jobFeedDaemonStatus {
 	return &jobFeedDaemonStatus{
 		JobBase: JobBase{
@@ -98,6 +126,7 @@ func newDaemonStatus(nodeID string) *jobFeedDaemonStatus {
 		JobRedis: JobRedis{
 			cachePendingH:   cachekeys.FeedDaemonStatusPendingH,
 			cachePendingIDX: nodeID,
+			cacheResultP:    cachekeys.FeedDaemonStatusP,
 		},
 		nodeID: nodeID,
 
@@ -128,6 +157,7 @@ func (d *jobFeedDaemonStatus) Operations() []operation {
 		{name: "dbCheckClusterIDForNodeID", do: d.dbCheckClusterIDForNodeID, blocking: true},
 		{name: "dbCheckClusters", do: d.dbCheckClusters, blocking: true},
 		{name: "dbFindNodes", do: d.dbFindNodes, blocking: true},
+		{name: "cacheDaemonEvents", do: d.cacheDaemonEvents},
 		{name: "dataToNodeFrozen", do: d.dataToNodeFrozen, blocking: true},
 		{name: "dataToNodeHeartbeat", do: d.dataToNodeHeartbeat, blocking: true},
 		{name: "heartbeatToDB", do: d.heartbeatToDB, blocking: true},
@@ -159,36 +189,59 @@ func (d *jobFeedDaemonStatus) LogResult() {
 	}
 }
 
+// getChanges takes the node daemon status changes not yet applied. The
+// changes set is atomically moved to the taken set, so the changes added by
+// the feeder meanwhile are never lost: they are either taken now, or left for
+// the next job queued by the feeder. The taken changes are removed from the
+// taken set only when the job commits, so a failed and requeued job, or the
+// job of a dead worker, takes them again.
 func (d *jobFeedDaemonStatus) getChanges(ctx context.Context) error {
-	s, err := d.redis.HGet(ctx, cachekeys.FeedDaemonStatusChangesH, d.nodeID).Result()
-	if err == nil {
-		// TODO: fix possible race:
-		// worker iteration 1: pickup changes 'a'
-		// listener: read previous changes 'a'
-		// listener: merge b => set changes from 'a' to 'a', 'b'
-		// listener: ask for new worker iteration 2
-		// worker iteration 1: delete changes the 'b' => 'b' change is lost
-		// worker iteration 1: ... done
-		// worker iteration 2: pickup changes: empty instead of expected 'b'
-		if err := d.redis.HDel(ctx, cachekeys.FeedDaemonStatusChangesH, d.nodeID).Err(); err != nil {
-			return fmt.Errorf("getChanges: HDEL %s %s: %w", cachekeys.FeedDaemonStatusChangesH, d.nodeID, err)
-		}
-	} else if !errors.Is(err, redis.Nil) {
-		return fmt.Errorf("getChanges: HGET %s %s: %w", cachekeys.FeedDaemonStatusChangesH, d.nodeID, err)
-	}
-	d.rawChanges = s
-	for _, change := range strings.Fields(s) {
+	keys := []string{cachekeys.FeedDaemonStatusChangesS + d.nodeID, cachekeys.FeedDaemonStatusTakenS + d.nodeID}
+	members, err := takeChangesScript.Run(ctx, d.redis, keys).StringSlice()
+	if err != nil {
+		return fmt.Errorf("getChanges: takeChangesScript %s: %w", keys[0], err)
+	}
+	d.rawChanges = strings.Join(members, " ")
+	for _, change := range members {
 		d.changes[change] = struct{}{}
 	}
 	return nil
 }
 
+// Commit commits the job transaction, then removes the applied changes from
+// the taken set.
+func (d *jobFeedDaemonStatus) Commit() error {
+	if err := d.JobDB.Commit(); err != nil {
+		return err
+	}
+	if len(d.changes) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(d.changes))
+	for change := range d.changes {
+		members = append(members, change)
+	}
+	key := cachekeys.FeedDaemonStatusTakenS + d.nodeID
+	// the changes are applied, the next job only takes them again if the
+	// removal fails
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := d.redis.SRem(ctx, key, members...).Err(); err != nil {
+		d.logger.Warn(fmt.Sprintf("SREM %s", key), logkey.Error, err)
+	}
+	return nil
+}
+
 func (d *jobFeedDaemonStatus) getData(ctx context.Context) error {
 	var (
 		err  error
 		data map[string]any
 	)
-	if b, err := d.redis.HGet(ctx, cachekeys.FeedDaemonStatusH, d.nodeID).Bytes(); err != nil {
+	if d.eventsClusterID != "" {
+		if err := d.getEventsData(ctx); err != nil {
+			return err
+		}
+	} else if b, err := d.redis.HGet(ctx, cachekeys.FeedDaemonStatusH, d.nodeID).Bytes(); err != nil {
 		return fmt.Errorf("getData: HGET %s %s: %w", cachekeys.FeedDaemonStatusH, d.nodeID, err)
 	} else if err = json.Unmarshal(b, &data); err != nil {
 		return fmt.Errorf("getData: unexpected data from %s %s: %w", cachekeys.FeedDaemonStatusH, d.nodeID, err)
@@ -211,6 +264,9 @@ func (d *jobFeedDaemonStatus) getData(ctx context.Context) error {
 	if d.clusterName, err = d.data.clusterName(); err != nil {
 		return fmt.Errorf("getData %s: %w", d.nodeID, err)
 	}
+	if d.eventsClusterID != "" && d.clusterID != d.eventsClusterID {
+		return fmt.Errorf("getData %s: cluster id %s differs from the daemon events cluster id %s", d.nodeID, d.clusterID, d.eventsClusterID)
+	}
 	return nil
 }
 
@@ -224,7 +280,7 @@ func (d *jobFeedDaemonStatus) dbCheckClusterIDForNodeID(ctx context.Context) err
 }
 
 func (d *jobFeedDaemonStatus) dbCheckClusters(ctx context.Context) error {
-	if err := d.oDb.UpdateClustersData(ctx, d.clusterName, d.clusterName, string(d.rawData)); err != nil {
+	if err := d.oDb.UpdateClustersData(ctx, d.clusterName, d.clusterID, string(d.rawData)); err != nil {
 		return fmt.Errorf("dbCheckClusters %s (%s): %w", d.nodeID, d.clusterID, err)
 	}
 	return nil
@@ -749,7 +805,7 @@ func (d *jobFeedDaemonStatus) dbUpdateInstances(ctx context.Context) error {
 
 			dashboardUpdateObjectFlexStartedL = append(dashboardUpdateObjectFlexStartedL, &cdb.DashboardUpdateObjectFlexStartedParams{
 				SvcID: objID,
-				Sev:   severityFromEnv(dashObjObjectFlexError, obj.Env),
+				Sev:   severityFor(dashObjObjectFlexError, obj),
 				Env:   obj.Env,
 			})
 
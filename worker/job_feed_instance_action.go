@@ -37,6 +37,19 @@ type jobFeedInstanceAction struct {
 	rawData []byte
 }
 
+func init() {
+	Register(JobType[InstanceActionIndex]{
+		Name:     jtInstanceAction,
+		Queue:    "instance_action",
+		PendingH: cachekeys.FeedInstanceActionPendingH,
+		DataH:    cachekeys.FeedInstanceActionH,
+		Codec:    instanceActionCodec{},
+		New: func(index InstanceActionIndex) JobRunner {
+			return newAction(index.Path, index.NodeID, index.ClusterID, index.UUID)
+		},
+	})
+}
+
 func newAction(objectName, nodeID, clusterID, uuid string) *jobFeedInstanceAction {
 	idX := fmt.Sprintf("%s@%s@%s:%s", objectName, nodeID, clusterID, uuid)
 
@@ -49,6 +62,7 @@ func newAction(objectName, nodeID, clusterID, uuid string) *jobFeedInstanceActio
 		JobRedis: JobRedis{
 			cachePendingH:   cachekeys.FeedInstanceActionPendingH,
 			cachePendingIDX: idX,
+			cacheResultP:    cachekeys.FeedInstanceActionP,
 		},
 		idX:        idX,
 		nodeID:     nodeID,
@@ -38,6 +38,19 @@ type (
 	}
 )
 
+func init() {
+	Register(JobType[InstanceIndex]{
+		Name:     jtInstanceResourceInfo,
+		Queue:    "instance_resource_info",
+		PendingH: cachekeys.FeedInstanceResourceInfoPendingH,
+		DataH:    cachekeys.FeedInstanceResourceInfoH,
+		Codec:    instanceCodec{},
+		New: func(index InstanceIndex) JobRunner {
+			return newjobFeedInstanceResourceInfo(index.Path, index.NodeID, index.ClusterID)
+		},
+	})
+}
+
 func newjobFeedInstanceResourceInfo(objectName, nodeID, clusterID string) *jobFeedInstanceResourceInfo {
 	idX := fmt.Sprintf("%s@%s@%s", objectName, nodeID, clusterID)
 	return &jobFeedInstanceResourceInfo{
@@ -49,6 +62,7 @@ func newjobFeedInstanceResourceInfo(objectName, nodeID, clusterID string) *jobFe
 		JobRedis: JobRedis{
 			cachePendingH:   cachekeys.FeedInstanceResourceInfoPendingH,
 			cachePendingIDX: idX,
+			cacheResultP:    cachekeys.FeedInstanceResourceInfoP,
 		},
 		idX:        idX,
 		nodeID:     nodeID,
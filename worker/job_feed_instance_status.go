@@ -43,6 +43,19 @@ type jobFeedInstanceStatus struct {
 	obj *cdb.DBObject
 }
 
+func init() {
+	Register(JobType[InstanceIndex]{
+		Name:     jtInstanceStatus,
+		Queue:    "instance_status",
+		PendingH: cachekeys.FeedInstanceStatusPendingH,
+		DataH:    cachekeys.FeedInstanceStatusH,
+		Codec:    instanceCodec{},
+		New: func(index InstanceIndex) JobRunner {
+			return newInstanceStatus(index.Path, index.NodeID, index.ClusterID)
+		},
+	})
+}
+
 func newInstanceStatus(objectName, nodeID, clusterID string) *jobFeedInstanceStatus {
 	idX := fmt.Sprintf("%s@%s@%s", objectName, nodeID, clusterID)
 	return &jobFeedInstanceStatus{
@@ -54,6 +67,7 @@ func newInstanceStatus(objectName, nodeID, clusterID string) *jobFeedInstanceSta
 		JobRedis: JobRedis{
 			cachePendingH:   cachekeys.FeedInstanceStatusPendingH,
 			cachePendingIDX: idX,
+			cacheResultP:    cachekeys.FeedInstanceStatusP,
 		},
 		idX:        idX,
 		nodeID:     nodeID,
@@ -71,7 +85,6 @@ func (d *jobFeedInstanceStatus) Operations() []operation {
 		{name: "findObjectFromDb", do: d.findObjectFromDb},
 		{name: "updateDB", do: d.updateDB},
 		{name: "pushFromTableChanges", do: d.pushFromTableChanges},
-		{name: "processed", do: d.processed},
 	}
 }
 
@@ -160,8 +173,3 @@ func (d *jobFeedInstanceStatus) updateDB(ctx context.Context) error {
 
 	return nil
 }
-
-func (d *jobFeedInstanceStatus) processed(ctx context.Context) error {
-	_ = d.redis.Publish(ctx, cachekeys.FeedInstanceStatusP, d.idX)
-	return nil
-}
@@ -27,6 +27,17 @@ type (
 	}
 )
 
+func init() {
+	Register(JobType[NodeDiskIndex]{
+		Name:     jtNodeDisk,
+		Queue:    "node_disk",
+		PendingH: cachekeys.FeedNodeDiskPendingH,
+		DataH:    cachekeys.FeedNodeDiskH,
+		Codec:    nodeDiskCodec{},
+		New:      func(index NodeDiskIndex) JobRunner { return newNodeDisk(index.Nodename, index.NodeID, index.ClusterID) },
+	})
+}
+
 func newNodeDisk(nodename, nodeID, clusterID string) *jobFeedNodeDisk {
 	return &jobFeedNodeDisk{
 		JobBase: JobBase{
@@ -37,6 +48,7 @@ func newNodeDisk(nodename, nodeID, clusterID string) *jobFeedNodeDisk {
 		JobRedis: JobRedis{
 			cachePendingH:   cachekeys.FeedNodeDiskPendingH,
 			cachePendingIDX: nodename + "@" + nodeID + "@" + clusterID,
+			cacheResultP:    cachekeys.FeedNodeDiskP,
 		},
 		clusterID: clusterID,
 		nodeID:    nodeID,
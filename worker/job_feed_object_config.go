@@ -38,6 +38,19 @@ type (
 	}
 )
 
+func init() {
+	Register(JobType[InstanceIndex]{
+		Name:     jtObjectConfig,
+		Queue:    "object_config",
+		PendingH: cachekeys.FeedObjectConfigPendingH,
+		DataH:    cachekeys.FeedObjectConfigH,
+		Codec:    instanceCodec{},
+		New: func(index InstanceIndex) JobRunner {
+			return newFeedObjectConfig(index.Path, index.NodeID, index.ClusterID)
+		},
+	})
+}
+
 func newFeedObjectConfig(objectName, nodeID, clusterID string) *jobFeedObjectConfig {
 	idX := fmt.Sprintf("%s@%s@%s", objectName, nodeID, clusterID)
 	return &jobFeedObjectConfig{
@@ -49,6 +62,7 @@ func newFeedObjectConfig(objectName, nodeID, clusterID string) *jobFeedObjectCon
 		JobRedis: JobRedis{
 			cachePendingH:   cachekeys.FeedObjectConfigPendingH,
 			cachePendingIDX: idX,
+			cacheResultP:    cachekeys.FeedObjectConfigP,
 		},
 		idX:        idX,
 		nodeID:     nodeID,
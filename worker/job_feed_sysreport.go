@@ -0,0 +1,427 @@
+package worker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/feeder"
+	"github.com/opensvc/oc3/util/git"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+type (
+	jobFeedSysreport struct {
+		JobBase
+		JobRedis
+		JobDB
+
+		nodeID string
+
+		// archive is the spooled tar archive file name
+		archive string
+
+		// deleted is the list of node files to remove from the node sysreport
+		// repository.
+		deleted []string
+
+		// commit is the node sysreport repository commit, nil if nothing changed
+		commit *git.CommitInfo
+
+		// head is the node sysreport repository HEAD commit when nothing
+		// changed. A previous run of the job may have committed it, then
+		// failed to insert its sysreport_log row.
+		head *git.CommitInfo
+	}
+
+	// sysreportIndex is the sysreport job index, the FeedSysreportQ value
+	// pushed by the feeder.
+	sysreportIndex feeder.SysreportData
+)
+
+const (
+	// sysreportLockTTL is the expiry of the node sysreport repository
+	// lock, renewed every third of its value while the job holds it.
+	sysreportLockTTL = time.Minute
+
+	// sysreportLockRetry is the delay between the lock acquisition tries.
+	sysreportLockRetry = 500 * time.Millisecond
+)
+
+var (
+	// renewSysreportLockScript resets the KEYS[1] lock expiry to ARGV[2] ms
+	// if it is held by ARGV[1]. It returns 1 if renewed, else 0.
+	renewSysreportLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+	// releaseSysreportLockScript deletes the KEYS[1] lock if it is held by
+	// ARGV[1]. It returns 1 if released, else 0.
+	releaseSysreportLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+)
+
+func init() {
+	Register(JobType[sysreportIndex]{
+		Name:  jtSysreport,
+		Queue: "sysreport",
+		Codec: jsonCodec[sysreportIndex]{valid: func(data sysreportIndex) error {
+			if data.NodeID == "" {
+				return fmt.Errorf("missing node_id")
+			}
+			return nil
+		}},
+		New: func(index sysreportIndex) JobRunner { return newSysreport(index.NodeID, index.Archive, index.Deleted) },
+	})
+}
+
+func (d sysreportIndex) nodeID() string { return d.NodeID }
+
+func newSysreport(nodeID, archive string, deleted []string) *jobFeedSysreport {
+	return &jobFeedSysreport{
+		JobBase: JobBase{
+			name:   jtSysreport,
+			detail: "nodeID: " + nodeID,
+			logger: slog.With(logkey.NodeID, nodeID, logkey.JobName, jtSysreport),
+		},
+		nodeID:  nodeID,
+		archive: archive,
+		deleted: deleted,
+	}
+}
+
+func (d *jobFeedSysreport) Operations() []operation {
+	hasCommit := func() bool { return d.commit != nil || d.head != nil }
+	return []operation{
+		{name: "updateRepository", do: d.updateRepository, blocking: true},
+		{name: "dbInsertSysreportLog", do: d.dbInsertSysreportLog, condition: hasCommit, blocking: true},
+		{name: "pushFromTableChanges", do: d.pushFromTableChanges},
+	}
+}
+
+func (d *jobFeedSysreport) LogResult() {
+	if d.commit == nil {
+		d.logger.Debug("sysreport unchanged")
+		return
+	}
+	d.logger.Info(fmt.Sprintf("sysreport commit %s: %d changes", d.commit.ID, len(d.commit.Changes)))
+}
+
+// updateRepository applies the deleted files and the spooled archive to the
+// node sysreport repository, then commits the changes.
+func (d *jobFeedSysreport) updateRepository(ctx context.Context) (err error) {
+	uploadDir := viper.GetString("scheduler.directories.uploads")
+	nodeDir := filepath.Join(uploadDir, "sysreport", d.nodeID)
+
+	archivePath := d.archivePath()
+
+	unlock, err := d.lockRepository(ctx)
+	if err != nil {
+		return fmt.Errorf("updateRepository: %w", err)
+	}
+	defer unlock()
+
+	if err := os.MkdirAll(nodeDir, 0755); err != nil {
+		return fmt.Errorf("updateRepository: %w", err)
+	}
+	d.deleteFiles(nodeDir)
+	if archivePath != "" {
+		if err := d.checkQuota(archivePath, nodeDir); err != nil {
+			return fmt.Errorf("updateRepository: %w", err)
+		}
+		if err := d.extractArchive(archivePath, nodeDir); err != nil {
+			return fmt.Errorf("updateRepository: extract %s: %w", archivePath, err)
+		}
+	}
+	if d.commit, err = git.Commit(ctx, nodeDir); err != nil {
+		return fmt.Errorf("updateRepository: %w", err)
+	}
+	if d.commit == nil {
+		if d.head, err = git.Head(ctx, nodeDir); err != nil {
+			return fmt.Errorf("updateRepository: %w", err)
+		}
+	}
+	return nil
+}
+
+// Commit commits the job transaction, then removes the spooled archive. The
+// archive is kept on the job failures, so the requeued entry can retry its
+// extraction.
+func (d *jobFeedSysreport) Commit() error {
+	if err := d.JobDB.Commit(); err != nil {
+		return err
+	}
+	if archivePath := d.archivePath(); archivePath != "" {
+		if err := os.Remove(archivePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			d.logger.Warn("remove spooled sysreport archive", logkey.Error, err)
+		}
+	}
+	return nil
+}
+
+// archivePath returns the spooled archive path, or "" if the feeder posted
+// only deleted files.
+func (d *jobFeedSysreport) archivePath() string {
+	if d.archive == "" {
+		return ""
+	}
+	return filepath.Join(viper.GetString("scheduler.directories.uploads"), "sysreport_spool", filepath.Base(d.archive))
+}
+
+// lockRepository waits for the lock of the node sysreport repository,
+// shared by the workers of all hosts, until ctx is done. The lock is renewed
+// until the returned unlock function is called.
+func (d *jobFeedSysreport) lockRepository(ctx context.Context) (unlock func(), err error) {
+	key := cachekeys.SysreportLock + d.nodeID
+	token := uuid.New().String()
+	for {
+		if ok, err := d.redis.SetNX(ctx, key, token, sysreportLockTTL).Result(); err != nil {
+			return nil, fmt.Errorf("lock %s: %w", key, err)
+		} else if ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("lock %s: %w", key, ctx.Err())
+		case <-time.After(sysreportLockRetry):
+		}
+	}
+
+	keepaliveCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(sysreportLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-keepaliveCtx.Done():
+				return
+			case <-ticker.C:
+				n, err := renewSysreportLockScript.Run(keepaliveCtx, d.redis, []string{key}, token, sysreportLockTTL.Milliseconds()).Int()
+				switch {
+				case err != nil && keepaliveCtx.Err() != nil:
+					return
+				case err != nil:
+					d.logger.Warn(fmt.Sprintf("renew lock %s", key), logkey.Error, err)
+				case n == 0:
+					d.logger.Warn(fmt.Sprintf("lock %s lost during the repository update", key))
+					return
+				}
+			}
+		}
+	}()
+
+	unlock = func() {
+		cancel()
+		<-done
+		releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer releaseCancel()
+		if err := releaseSysreportLockScript.Run(releaseCtx, d.redis, []string{key}, token).Err(); err != nil {
+			d.logger.Warn(fmt.Sprintf("release lock %s", key), logkey.Error, err)
+		}
+	}
+	return unlock, nil
+}
+
+func (d *jobFeedSysreport) deleteFiles(nodeDir string) {
+	for _, fpath := range d.deleted {
+		fpath = strings.TrimSpace(fpath)
+		var relpath string
+		if filepath.IsAbs(fpath) {
+			relpath = "file" + fpath
+		} else {
+			relpath = filepath.Join("file", fpath)
+		}
+		pathToDelete := filepath.Join(nodeDir, relpath)
+		if !isInDir(pathToDelete, nodeDir) {
+			d.logger.Warn(fmt.Sprintf("deleteFiles: skip %s: escapes the node directory", fpath))
+			continue
+		}
+		if git.HasGitDirElem(relpath) {
+			d.logger.Warn(fmt.Sprintf("deleteFiles: skip %s: in the repository .git directory", fpath))
+			continue
+		}
+		if err := os.Remove(pathToDelete); err != nil && !os.IsNotExist(err) {
+			d.logger.Warn("deleteFiles", logkey.Error, err)
+		}
+	}
+}
+
+// extractArchive extracts the archive regular files to nodeDir. The archive
+// entries first path element is replaced by nodeDir. Links are never
+// materialized, the feeder has already rejected the archives with unsafe
+// entries, but the entries escaping nodeDir or in its .git directory are
+// checked again.
+func (d *jobFeedSysreport) extractArchive(archivePath, nodeDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		idx := strings.Index(header.Name, "/")
+		if idx == -1 {
+			continue
+		}
+		targetPath := filepath.Join(nodeDir, header.Name[idx:])
+		if !isInDir(targetPath, nodeDir) {
+			return fmt.Errorf("entry %s escapes the node directory", header.Name)
+		}
+		if git.HasGitDirElem(header.Name[idx:]) {
+			return fmt.Errorf("entry %s is in the repository .git directory", header.Name)
+		}
+		if err := extractFile(tr, targetPath, fs.FileMode(header.Mode)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkQuota returns an error if the archive extraction would grow the node
+// directory beyond the feeder.sysreport.max_bytes and max_entries per node
+// quotas. The bytes account for the git history, the entries are the work
+// tree files. An archive file replacing a node file only accounts for its
+// size difference.
+func (d *jobFeedSysreport) checkQuota(archivePath, nodeDir string) error {
+	maxBytes := int64(viper.GetSizeInBytes("feeder.sysreport.max_bytes"))
+	maxEntries := viper.GetInt("feeder.sysreport.max_entries")
+	if maxBytes <= 0 && maxEntries <= 0 {
+		return nil
+	}
+
+	var (
+		size    int64
+		entries int
+	)
+	err := filepath.WalkDir(nodeDir, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !e.Type().IsRegular() {
+			return nil
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		if rel, _ := filepath.Rel(nodeDir, p); !git.HasGitDirElem(rel) {
+			entries++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("quota: %w", err)
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("quota: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("quota: %w", err)
+		}
+		idx := strings.Index(header.Name, "/")
+		if header.Typeflag != tar.TypeReg || idx == -1 {
+			continue
+		}
+		size += header.Size
+		if info, err := os.Lstat(filepath.Join(nodeDir, header.Name[idx:])); err == nil && info.Mode().IsRegular() {
+			size -= info.Size()
+		} else {
+			entries++
+		}
+	}
+
+	switch {
+	case maxBytes > 0 && size > maxBytes:
+		return fmt.Errorf("node sysreport quota exceeded: %d bytes, max %d", size, maxBytes)
+	case maxEntries > 0 && entries > maxEntries:
+		return fmt.Errorf("node sysreport quota exceeded: %d entries, max %d", entries, maxEntries)
+	}
+	return nil
+}
+
+// isInDir returns true if the cleaned path p is dir or a dir descendant
+func isInDir(p, dir string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func extractFile(r io.Reader, targetPath string, mode fs.FileMode) error {
+	if info, err := os.Stat(targetPath); err == nil {
+		// enable write
+		_ = os.Chmod(targetPath, info.Mode()|0200)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+
+	outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_RDWR|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(outFile, r); err != nil {
+		_ = outFile.Close()
+		return err
+	}
+	if err := outFile.Close(); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(targetPath); err == nil {
+		// restore read only
+		_ = os.Chmod(targetPath, info.Mode()|0400)
+	}
+	return nil
+}
+
+// dbInsertSysreportLog inserts the sysreport_log row of the new commit, or
+// of the HEAD commit if its row is missing.
+func (d *jobFeedSysreport) dbInsertSysreportLog(ctx context.Context) error {
+	if d.commit != nil {
+		return d.oDb.SysreportLogInsert(ctx, d.nodeID, d.commit)
+	}
+	return d.oDb.SysreportLogInsert(ctx, d.nodeID, d.head)
+}
@@ -0,0 +1,152 @@
+package worker
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/opensvc/oc3/cdb"
+)
+
+func writeTestArchive(t *testing.T, archivePath string, files map[string]int) {
+	t.Helper()
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(f)
+	for name, size := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(size), Mode: 0644}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(make([]byte, size)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSysreportExtractArchiveRejectsGitDir(t *testing.T) {
+	dir := t.TempDir()
+	nodeDir := filepath.Join(dir, "node")
+	archivePath := filepath.Join(dir, "archive.tar")
+
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(f)
+	content := []byte("#!/bin/sh\n")
+	for _, name := range []string{"node1/file/etc/hosts", "node1/.git/hooks/post-commit"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(len(content)), Mode: 0755}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := newSysreport("node1", archivePath, nil)
+	if err := d.extractArchive(archivePath, nodeDir); err == nil {
+		t.Fatal("expected an error on the .git/hooks entry")
+	}
+	if _, err := os.Stat(filepath.Join(nodeDir, ".git", "hooks", "post-commit")); !os.IsNotExist(err) {
+		t.Fatalf("the .git/hooks entry was written: %v", err)
+	}
+}
+
+func TestSysreportDeleteFilesSkipsGitDir(t *testing.T) {
+	nodeDir := t.TempDir()
+	gitConfig := filepath.Join(nodeDir, "file", ".git", "config")
+	if err := os.MkdirAll(filepath.Dir(gitConfig), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(gitConfig, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	d := newSysreport("node1", "", []string{"/.git/config"})
+	d.deleteFiles(nodeDir)
+	if _, err := os.Stat(gitConfig); err != nil {
+		t.Fatalf("the .git path was deleted: %v", err)
+	}
+}
+
+func TestSysreportCheckQuota(t *testing.T) {
+	viper.Set("feeder.sysreport.max_bytes", "1000")
+	viper.Set("feeder.sysreport.max_entries", 3)
+	defer viper.Set("feeder.sysreport.max_bytes", nil)
+	defer viper.Set("feeder.sysreport.max_entries", nil)
+
+	dir := t.TempDir()
+	nodeDir := filepath.Join(dir, "node")
+	for name, size := range map[string]int{"file/etc/hosts": 400, ".git/objects/aa/bb": 300} {
+		p := filepath.Join(nodeDir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d := newSysreport("node1", "", nil)
+	cases := []struct {
+		name  string
+		files map[string]int
+		ok    bool
+	}{
+		{"replace within the bytes quota", map[string]int{"node1/file/etc/hosts": 600}, true},
+		{"new file within the bytes quota", map[string]int{"node1/file/etc/fstab": 300}, true},
+		{"bytes quota accounts for the git history", map[string]int{"node1/file/etc/fstab": 301}, false},
+		{"replace beyond the bytes quota", map[string]int{"node1/file/etc/hosts": 701}, false},
+		{"entries quota", map[string]int{"node1/file/a": 1, "node1/file/b": 1}, true},
+		{"beyond the entries quota", map[string]int{"node1/file/a": 1, "node1/file/b": 1, "node1/file/c": 1}, false},
+	}
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			archivePath := filepath.Join(dir, fmt.Sprintf("archive%d.tar", i))
+			writeTestArchive(t, archivePath, tc.files)
+			err := d.checkQuota(archivePath, nodeDir)
+			if tc.ok && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			} else if !tc.ok && err == nil {
+				t.Errorf("expected a quota error")
+			}
+		})
+	}
+}
+
+func TestSysreportCommitRemovesArchive(t *testing.T) {
+	dir := t.TempDir()
+	viper.Set("scheduler.directories.uploads", dir)
+	defer viper.Set("scheduler.directories.uploads", nil)
+
+	d := newSysreport("node1", "archive.tar", nil)
+	d.oDb = cdb.New(nil)
+	archivePath := d.archivePath()
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestArchive(t, archivePath, map[string]int{"node1/file/etc/hosts": 1})
+
+	if err := d.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(archivePath); !os.IsNotExist(err) {
+		t.Fatalf("archive not removed on commit: %v", err)
+	}
+}
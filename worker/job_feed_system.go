@@ -24,6 +24,17 @@ type (
 	}
 )
 
+func init() {
+	Register(JobType[NodeIndex]{
+		Name:     jtNodeSystem,
+		Queue:    "system",
+		PendingH: cachekeys.FeedSystemPendingH,
+		DataH:    cachekeys.FeedSystemH,
+		Codec:    nodeCodec{},
+		New:      func(index NodeIndex) JobRunner { return newDaemonSystem(string(index)) },
+	})
+}
+
 func newDaemonSystem(nodeID string) *jobFeedSystem {
 	return &jobFeedSystem{
 		JobBase: JobBase{
@@ -34,6 +45,7 @@ func newDaemonSystem(nodeID string) *jobFeedSystem {
 		JobRedis: JobRedis{
 			cachePendingH:   cachekeys.FeedSystemPendingH,
 			cachePendingIDX: nodeID,
+			cacheResultP:    cachekeys.FeedSystemP,
 		},
 		nodeID: nodeID,
 	}
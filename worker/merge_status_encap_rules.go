@@ -1,66 +1,155 @@
 package worker
 
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+type (
+	// StatusMergeRule merges the Hypervisor status of an instance with the
+	// Encap status of one of its containers into the container instance
+	// Status. The empty statuses are merged as "n/a".
+	StatusMergeRule struct {
+		Hypervisor string
+		Encap      string
+		Status     string
+	}
+
+	// statusMergeTable is the merge table of a status kind, indexed by
+	// "<hypervisor status>,<encap status>".
+	statusMergeTable map[string]statusMerge
+
+	statusMerge struct {
+		status string
+
+		// source is "default" or "config"
+		source string
+	}
+)
+
+const (
+	// statusKindAvail is the kind of the availability status, and of the
+	// status groups (ip, disk, fs, share, container, app, sync).
+	statusKindAvail = "avail"
+
+	// statusKindOverall is the kind of the overall status
+	statusKindOverall = "overall"
+)
+
 var (
-	// hypervisorContainerMergeMap is the merge map rules from hypervisor +
-	// encap instance status:
-	// map[<hypervisor status>,<encap status>] -> <merged status>
-	// TODO: confirm zero status "" have same meaning as "n/a"
-	// TODO: or add "undef" status ?
-	hypervisorContainerMergeMap = map[string]string{
-		"up,up":         "up",
-		"up,down":       "warn",
-		"up,warn":       "warn",
-		"up,n/a":        "up",
-		"up,":           "up",
-		"up,stdby up":   "up",
-		"up,stdby down": "warn",
-
-		"down,up":         "warn",
-		"down,down":       "down",
-		"down,warn":       "warn",
-		"down,n/a":        "down",
-		"down,":           "down",
-		"down,stdby up":   "stdby up",
-		"down,stdby down": "stdby down",
-
-		"warn,up":         "warn",
-		"warn,down":       "down",
-		"warn,warn":       "warn",
-		"warn,n/a":        "warn",
-		"warn,":           "warn",
-		"warn,stdby up":   "warn",
-		"warn,stdby down": "warn",
-
-		"n/a,up":         "up",
-		"n/a,down":       "down",
-		"n/a,warn":       "warn",
-		"n/a,n/a":        "n/a",
-		"n/a,":           "n/a",
-		"n/a,stdby up":   "stdby up",
-		"n/a,stdby down": "stdby down",
-
-		"stdby up,up":         "up",
-		"stdby up,down":       "stdby up",
-		"stdby up,warn":       "warn",
-		"stdby up,n/a":        "stdby up",
-		"stdby up,":           "stdby up",
-		"stdby up,stdby up":   "stdby up",
-		"stdby up,stdby down": "warn",
-
-		"stdby down,up":         "warn",
-		"stdby down,down":       "stdby down",
-		"stdby down,warn":       "warn",
-		"stdby down,n/a":        "stdby down",
-		"stdby down,":           "stdby down",
-		"stdby down,stdby up":   "warn",
-		"stdby down,stdby down": "stdby down",
-
-		",up":         "up",
-		",down":       "down",
-		",warn":       "warn",
-		",n/a":        "n/a",
-		",":           "n/a",
-		",stdby up":   "stdby up",
-		",stdby down": "stdby down",
+	// hypervisorContainerMergeRules are the default merge rules of the
+	// hypervisor + encap instance status.
+	hypervisorContainerMergeRules = []StatusMergeRule{
+		{"up", "up", "up"},
+		{"up", "down", "warn"},
+		{"up", "warn", "warn"},
+		{"up", "n/a", "up"},
+		{"up", "stdby up", "up"},
+		{"up", "stdby down", "warn"},
+
+		{"down", "up", "warn"},
+		{"down", "down", "down"},
+		{"down", "warn", "warn"},
+		{"down", "n/a", "down"},
+		{"down", "stdby up", "stdby up"},
+		{"down", "stdby down", "stdby down"},
+
+		{"warn", "up", "warn"},
+		{"warn", "down", "down"},
+		{"warn", "warn", "warn"},
+		{"warn", "n/a", "warn"},
+		{"warn", "stdby up", "warn"},
+		{"warn", "stdby down", "warn"},
+
+		{"n/a", "up", "up"},
+		{"n/a", "down", "down"},
+		{"n/a", "warn", "warn"},
+		{"n/a", "n/a", "n/a"},
+		{"n/a", "stdby up", "stdby up"},
+		{"n/a", "stdby down", "stdby down"},
+
+		{"stdby up", "up", "up"},
+		{"stdby up", "down", "stdby up"},
+		{"stdby up", "warn", "warn"},
+		{"stdby up", "n/a", "stdby up"},
+		{"stdby up", "stdby up", "stdby up"},
+		{"stdby up", "stdby down", "warn"},
+
+		{"stdby down", "up", "warn"},
+		{"stdby down", "down", "stdby down"},
+		{"stdby down", "warn", "warn"},
+		{"stdby down", "n/a", "stdby down"},
+		{"stdby down", "stdby up", "warn"},
+		{"stdby down", "stdby down", "stdby down"},
+	}
+
+	// defaultStatusMergeRules are the default merge rules by status kind
+	defaultStatusMergeRules = map[string][]StatusMergeRule{
+		statusKindAvail:   hypervisorContainerMergeRules,
+		statusKindOverall: hypervisorContainerMergeRules,
 	}
+
+	// statusMergeTables are the merge tables by status kind
+	statusMergeTables atomic.Pointer[map[string]statusMergeTable]
 )
+
+func init() {
+	if err := setStatusMergeRules(nil); err != nil {
+		panic(err)
+	}
+}
+
+// StatusMergeKinds returns the sorted status kinds of the merge rules.
+func StatusMergeKinds() []string {
+	return slices.Sorted(maps.Keys(defaultStatusMergeRules))
+}
+
+// setStatusMergeRules replaces the merge tables by the default rules,
+// overridden by the rules by status kind.
+func setStatusMergeRules(rules map[string][]StatusMergeRule) error {
+	tables := make(map[string]statusMergeTable)
+	add := func(kind string, l []StatusMergeRule, source string) {
+		for _, rule := range l {
+			key := mergeStatusKey(rule.Hypervisor, rule.Encap)
+			tables[kind][key] = statusMerge{status: rule.Status, source: source}
+		}
+	}
+	for kind, l := range defaultStatusMergeRules {
+		tables[kind] = make(statusMergeTable)
+		add(kind, l, "default")
+	}
+	for kind, l := range rules {
+		if _, ok := tables[kind]; !ok {
+			return fmt.Errorf("unknown status merge kind %q, expected one of: %s", kind, strings.Join(StatusMergeKinds(), ", "))
+		}
+		add(kind, l, "config")
+	}
+	statusMergeTables.Store(&tables)
+	return nil
+}
+
+func mergeStatusKey(hypervisor, encap string) string {
+	if hypervisor == "" {
+		hypervisor = "n/a"
+	}
+	if encap == "" {
+		encap = "n/a"
+	}
+	return hypervisor + "," + encap
+}
+
+// mergeStatus returns the merged status of the hypervisor and encap
+// statuses of the status kind, and the description of the applied rule,
+// like "avail: up,down -> warn (default)". The merged status is empty when
+// no rule matches.
+func mergeStatus(kind, hypervisor, encap string) (string, string) {
+	key := mergeStatusKey(hypervisor, encap)
+	merge, ok := (*statusMergeTables.Load())[kind][key]
+	if !ok {
+		return "", fmt.Sprintf("%s: %s -> no rule", kind, key)
+	}
+	return merge.status, fmt.Sprintf("%s: %s -> %s (%s)", kind, key, merge.status, merge.source)
+}
@@ -15,7 +15,7 @@ var (
 			Namespace: "oc3",
 			Name:      "feed_job_executions_total",
 			Help: fmt.Sprintf("Total number of feed job executions (job_type={%s}, status={%s})",
-				strings.Join([]string{jtDaemonPing, jtDaemonStatus, jtNodeSystem, jtInstanceAction, jtInstanceResourceInfo, jtInstanceStatus, jtNodeDisk, jtObjectConfig}, "|"),
+				strings.Join([]string{jtDaemonEvents, jtDaemonPing, jtDaemonStatus, jtNodeSystem, jtInstanceAction, jtInstanceResourceInfo, jtInstanceStatus, jtChecks, jtNodeDisk, jtObjectConfig, jtSysreport}, "|"),
 				strings.Join([]string{jobStatusFailed, jobStatusOk}, "|")),
 		},
 		[]string{"job_type", "status"},
@@ -27,7 +27,7 @@ var (
 			Namespace: "oc3",
 			Name:      "feed_job_duration_seconds",
 			Help: fmt.Sprintf("Duration of entire feed job executions in seconds (job_type={%s}, status={%s})",
-				strings.Join([]string{jtDaemonPing, jtDaemonStatus, jtNodeSystem, jtInstanceAction, jtInstanceResourceInfo, jtInstanceStatus, jtNodeDisk, jtObjectConfig}, "|"),
+				strings.Join([]string{jtDaemonEvents, jtDaemonPing, jtDaemonStatus, jtNodeSystem, jtInstanceAction, jtInstanceResourceInfo, jtInstanceStatus, jtChecks, jtNodeDisk, jtObjectConfig, jtSysreport}, "|"),
 				strings.Join([]string{jobStatusFailed, jobStatusOk}, "|")),
 			Buckets: prometheus.DefBuckets,
 		},
@@ -40,10 +40,62 @@ var (
 			Namespace: "oc3",
 			Name:      "feed_job_step_duration_seconds",
 			Help: fmt.Sprintf("Duration of individual steps within feed jobs in seconds (job_type={%s}, status={%s}, job_step={main|...}})",
-				strings.Join([]string{jtDaemonPing, jtDaemonStatus, jtNodeSystem, jtInstanceAction, jtInstanceResourceInfo, jtInstanceStatus, jtNodeDisk, jtObjectConfig}, "|"),
+				strings.Join([]string{jtDaemonEvents, jtDaemonPing, jtDaemonStatus, jtNodeSystem, jtInstanceAction, jtInstanceResourceInfo, jtInstanceStatus, jtChecks, jtNodeDisk, jtObjectConfig, jtSysreport}, "|"),
 				strings.Join([]string{jobStatusFailed, jobStatusOk}, "|")),
 			Buckets: prometheus.DefBuckets,
 		},
 		[]string{"job_type", "job_step", "status"},
 	)
+
+	// Length of the feed queues
+	feedQueueLength = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "oc3",
+			Name:      "feed_queue_length",
+			Help:      "Number of entries of the feed queues (queue={daemon_status|...})",
+		},
+		[]string{"queue"},
+	)
+
+	// Size of the feed queue pending hashes
+	feedPendingSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "oc3",
+			Name:      "feed_queue_pending_size",
+			Help:      "Number of entries of the feed queue pending hashes (queue={daemon_status|...})",
+		},
+		[]string{"queue"},
+	)
+
+	// Latency between the feed queue entry push and the feed job start
+	feedQueueLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "oc3",
+			Name:      "feed_queue_latency_seconds",
+			Help:      "Latency between the feed queue entry enqueue and the feed job start in seconds (queue={daemon_status|...})",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 16),
+		},
+		[]string{"queue"},
+	)
+
+	// Failed feed queue entries
+	feedFailedEntries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "oc3",
+			Name:      "feed_queue_failed_entries_total",
+			Help: fmt.Sprintf("Total number of failed feed queue entries (queue={daemon_status|...}, reason={%s}, action={requeued|pending|dead_letter})",
+				strings.Join([]string{failReasonJob, failReasonWorker}, "|")),
+		},
+		[]string{"queue", "reason", "action"},
+	)
+
+	// Stale feed queue entries
+	feedStaleEntries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "oc3",
+			Name:      "feed_queue_stale_entries_total",
+			Help:      "Total number of feed queue entries skipped because older than the queue max age (queue={daemon_status|...})",
+		},
+		[]string{"queue"},
+	)
 )
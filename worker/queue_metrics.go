@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+// watchQueues updates the feed queues length and pending hashes size gauges
+// every interval, until ctx is done. All the feed queues are watched, not
+// only the worker queues.
+func (w *Worker) watchQueues(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.updateQueueMetrics(ctx); err != nil {
+			slog.Warn("update queue metrics", logkey.Error, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) updateQueueMetrics(ctx context.Context) error {
+	queues, err := w.scanKeys(ctx, cachekeys.QueuePrefix+"*")
+	if err != nil {
+		return err
+	}
+	pendings, err := w.scanKeys(ctx, cachekeys.PendingPrefix+"*"+cachekeys.PendingSuffix)
+	if err != nil {
+		return err
+	}
+	var (
+		queueCmds   = make(map[string]*redis.IntCmd)
+		pendingCmds = make(map[string]*redis.IntCmd)
+	)
+	if _, err := w.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range queues {
+			queueCmds[queueName(key)] = pipe.LLen(ctx, key)
+		}
+		for _, key := range pendings {
+			pendingCmds[pendingHashQueueName(key)] = pipe.HLen(ctx, key)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("LLEN and HLEN: %w", err)
+	}
+
+	// reset to drop the gauges of the deleted keys: redis deletes the empty
+	// lists and hashes.
+	feedQueueLength.Reset()
+	for name, cmd := range queueCmds {
+		feedQueueLength.With(prometheus.Labels{"queue": name}).Set(float64(cmd.Val()))
+	}
+	feedPendingSize.Reset()
+	for name, cmd := range pendingCmds {
+		feedPendingSize.With(prometheus.Labels{"queue": name}).Set(float64(cmd.Val()))
+	}
+	return nil
+}
+
+func (w *Worker) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iter := w.Redis.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("SCAN %s: %w", pattern, err)
+	}
+	return keys, nil
+}
+
+// queueName returns the queue name of a feed queue key:
+// oc3:q:feed_daemon_status => daemon_status
+func queueName(key string) string {
+	return strings.TrimPrefix(key, cachekeys.QueuePrefix)
+}
+
+// pendingHashQueueName returns the queue name of a feed queue pending hash key:
+// oc3:h:feed_daemon_status_pending => daemon_status
+func pendingHashQueueName(key string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(key, cachekeys.PendingPrefix), cachekeys.PendingSuffix)
+}
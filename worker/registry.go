@@ -0,0 +1,299 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/opensvc/oc3/cachekeys"
+)
+
+type (
+	// JobType declares a feed job type: its queue, the decoding of its
+	// queue entries, and the constructor of its jobs. The job types
+	// register themselves with Register.
+	JobType[T any] struct {
+		// Name is the job type name, like "daemonStatus".
+		Name string
+
+		// Queue is the queue name, like "daemon_status". The queue key is
+		// cachekeys.QueuePrefix + Queue.
+		Queue string
+
+		// PendingH is the key of the hash of the queued entries not yet
+		// dequeued. Empty when the feeder doesn't deduplicate the entries.
+		PendingH string
+
+		// DataH is the key of the hash of the payloads posted to the
+		// feeder, indexed by queue entry. Empty when the payloads are not
+		// stored in a hash.
+		DataH string
+
+		// Codec encodes and decodes the queue entries.
+		Codec IndexCodec[T]
+
+		// New returns the job of a decoded queue entry.
+		New func(index T) JobRunner
+	}
+
+	// IndexCodec encodes a job index to a queue entry, and decodes a queue
+	// entry to a job index.
+	IndexCodec[T any] interface {
+		Encode(index T) string
+		Decode(s string) (T, error)
+	}
+
+	// registeredJobType is the type independent interface of the
+	// registered JobType.
+	registeredJobType interface {
+		name() string
+		queueName() string
+		pendingH() string
+		dataH() string
+		newJob(entry string) (JobRunner, error)
+		nodeID(entry string) string
+	}
+
+	// nodeIDer is implemented by the indexes of the jobs of a node
+	nodeIDer interface {
+		nodeID() string
+	}
+
+	// NodeIndex is the index of the node jobs: the node id.
+	NodeIndex string
+
+	// InstanceIndex is the index of the object instance jobs, encoded as
+	// <path>@<node id>@<cluster id>.
+	InstanceIndex struct {
+		Path      string
+		NodeID    string
+		ClusterID string
+	}
+
+	// InstanceActionIndex is the index of the object instance action jobs,
+	// encoded as <path>@<node id>@<cluster id>:<action uuid>.
+	InstanceActionIndex struct {
+		InstanceIndex
+		UUID string
+	}
+
+	// NodeDiskIndex is the index of the node disk jobs, encoded as
+	// <nodename>@<node id>@<cluster id>.
+	NodeDiskIndex struct {
+		Nodename  string
+		NodeID    string
+		ClusterID string
+	}
+
+	// NodeClusterIndex is the index of the node jobs of a cluster, encoded
+	// as <node id>@<cluster id>.
+	NodeClusterIndex struct {
+		NodeID    string
+		ClusterID string
+	}
+
+	nodeCodec           struct{}
+	nodeClusterCodec    struct{}
+	instanceCodec       struct{}
+	instanceActionCodec struct{}
+	nodeDiskCodec       struct{}
+
+	// jsonCodec is the codec of the json encoded indexes. The decoded index
+	// is checked by valid, when not nil.
+	jsonCodec[T any] struct {
+		valid func(T) error
+	}
+)
+
+var (
+	registry   = make(map[string]registeredJobType)
+	registryMu sync.RWMutex
+)
+
+// Register adds the job type to the registry. It panics if a job type is
+// already registered for the same queue.
+func Register[T any](jt JobType[T]) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if jt.Name == "" || jt.Queue == "" || jt.Codec == nil || jt.New == nil {
+		panic(fmt.Sprintf("register job type %q: missing name, queue, codec or constructor", jt.Queue))
+	}
+	if _, ok := registry[jt.Queue]; ok {
+		panic(fmt.Sprintf("register job type %q: queue already registered", jt.Queue))
+	}
+	registry[jt.Queue] = jt
+}
+
+// QueueNames returns the sorted names of the registered queues.
+func QueueNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	l := make([]string, 0, len(registry))
+	for name := range registry {
+		l = append(l, name)
+	}
+	sort.Strings(l)
+	return l
+}
+
+// IsQueueName returns true if a job type is registered for the queue name.
+func IsQueueName(name string) bool {
+	_, ok := lookupJobType(cachekeys.QueuePrefix + name)
+	return ok
+}
+
+// lookupJobType returns the job type registered for the queue key.
+func lookupJobType(queue string) (registeredJobType, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	jt, ok := registry[queueName(queue)]
+	return jt, ok
+}
+
+// lookupJobTypeByName returns the job type registered with the job type
+// name or the queue name.
+func lookupJobTypeByName(name string) (registeredJobType, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if jt, ok := registry[name]; ok {
+		return jt, true
+	}
+	for _, jt := range registry {
+		if jt.name() == name {
+			return jt, true
+		}
+	}
+	return nil, false
+}
+
+func (jt JobType[T]) name() string { return jt.Name }
+
+func (jt JobType[T]) queueName() string { return jt.Queue }
+
+func (jt JobType[T]) pendingH() string { return jt.PendingH }
+
+func (jt JobType[T]) dataH() string { return jt.DataH }
+
+// nodeID returns the node id of the queue entry, or "" if the entry can't
+// be decoded.
+func (jt JobType[T]) nodeID(entry string) string {
+	index, err := jt.Codec.Decode(entry)
+	if err != nil {
+		return ""
+	}
+	if i, ok := any(index).(nodeIDer); ok {
+		return i.nodeID()
+	}
+	return ""
+}
+
+func (jt JobType[T]) newJob(entry string) (JobRunner, error) {
+	index, err := jt.Codec.Decode(entry)
+	if err != nil {
+		return nil, fmt.Errorf("%w: feed %s: %w", errInvalidIndex, jt.Queue, err)
+	}
+	return jt.New(index), nil
+}
+
+// splitIndex splits s in n non-empty sep separated fields
+func splitIndex(s, sep string, n int) ([]string, error) {
+	l := strings.Split(s, sep)
+	if len(l) != n {
+		return nil, fmt.Errorf("unexpected index: %s", s)
+	}
+	for _, e := range l {
+		if e == "" {
+			return nil, fmt.Errorf("unexpected index: %s", s)
+		}
+	}
+	return l, nil
+}
+
+func (i NodeIndex) nodeID() string { return string(i) }
+
+func (i NodeClusterIndex) nodeID() string { return i.NodeID }
+
+func (i InstanceIndex) nodeID() string { return i.NodeID }
+
+func (i NodeDiskIndex) nodeID() string { return i.NodeID }
+
+func (nodeCodec) Encode(index NodeIndex) string { return string(index) }
+
+func (nodeCodec) Decode(s string) (NodeIndex, error) {
+	if s == "" {
+		return "", fmt.Errorf("empty index")
+	}
+	return NodeIndex(s), nil
+}
+
+func (nodeClusterCodec) Encode(index NodeClusterIndex) string {
+	return index.NodeID + "@" + index.ClusterID
+}
+
+func (nodeClusterCodec) Decode(s string) (NodeClusterIndex, error) {
+	l, err := splitIndex(s, "@", 2)
+	if err != nil {
+		return NodeClusterIndex{}, err
+	}
+	return NodeClusterIndex{NodeID: l[0], ClusterID: l[1]}, nil
+}
+
+func (instanceCodec) Encode(index InstanceIndex) string {
+	return index.Path + "@" + index.NodeID + "@" + index.ClusterID
+}
+
+func (instanceCodec) Decode(s string) (InstanceIndex, error) {
+	l, err := splitIndex(s, "@", 3)
+	if err != nil {
+		return InstanceIndex{}, err
+	}
+	return InstanceIndex{Path: l[0], NodeID: l[1], ClusterID: l[2]}, nil
+}
+
+func (instanceActionCodec) Encode(index InstanceActionIndex) string {
+	return instanceCodec{}.Encode(index.InstanceIndex) + ":" + index.UUID
+}
+
+func (instanceActionCodec) Decode(s string) (InstanceActionIndex, error) {
+	l, err := splitIndex(s, ":", 2)
+	if err != nil {
+		return InstanceActionIndex{}, err
+	}
+	instance, err := instanceCodec{}.Decode(l[0])
+	if err != nil {
+		return InstanceActionIndex{}, fmt.Errorf("unexpected index: %s", s)
+	}
+	return InstanceActionIndex{InstanceIndex: instance, UUID: l[1]}, nil
+}
+
+func (nodeDiskCodec) Encode(index NodeDiskIndex) string {
+	return index.Nodename + "@" + index.NodeID + "@" + index.ClusterID
+}
+
+func (nodeDiskCodec) Decode(s string) (NodeDiskIndex, error) {
+	l, err := splitIndex(s, "@", 3)
+	if err != nil {
+		return NodeDiskIndex{}, fmt.Errorf("expected `nodename`@`nodeID`@`clusterID` found: %s", s)
+	}
+	return NodeDiskIndex{Nodename: l[0], NodeID: l[1], ClusterID: l[2]}, nil
+}
+
+func (c jsonCodec[T]) Encode(index T) string {
+	b, _ := json.Marshal(index)
+	return string(b)
+}
+
+func (c jsonCodec[T]) Decode(s string) (T, error) {
+	var index T
+	if err := json.Unmarshal([]byte(s), &index); err != nil {
+		return index, fmt.Errorf("unexpected index: %s", s)
+	}
+	if c.valid != nil {
+		if err := c.valid(index); err != nil {
+			return index, fmt.Errorf("unexpected index: %s: %w", s, err)
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,295 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/opensvc/oc3/cachekeys"
+	"github.com/opensvc/oc3/util/logkey"
+)
+
+const (
+	// deadLetterMaxLength is the maximum length of the dead letter list,
+	// the older entries are dropped.
+	deadLetterMaxLength = 10000
+
+	// failReasonJob is the failed entry reason of a job failure
+	failReasonJob = "job_failed"
+
+	// failReasonWorker is the failed entry reason of the reaped entries of a
+	// dead worker
+	failReasonWorker = "worker_died"
+)
+
+var (
+	// errInvalidIndex is wrapped by the runJob errors of the entries that
+	// can't be decoded to a job. These entries are moved to the dead letter
+	// list without retry.
+	errInvalidIndex = errors.New("invalid index")
+
+	// skipStaleScript removes the ARGV[1] field of the KEYS[1] pending hash
+	// if its enqueue unix ms value is older than ARGV[2] ms. It returns the
+	// age in ms of the removed field, else -1.
+	skipStaleScript = redis.NewScript(`
+local v = tonumber(redis.call('HGET', KEYS[1], ARGV[1]))
+if not v then
+	return -1
+end
+local t = redis.call('TIME')
+local age = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000) - v
+if age <= tonumber(ARGV[2]) then
+	return -1
+end
+redis.call('HDEL', KEYS[1], ARGV[1])
+return age
+`)
+
+	// failEntryScript removes a failed entry ARGV[1] from the KEYS[1]
+	// processing list, and increments its KEYS[3] attempts counter.
+	// The entry is then moved to the KEYS[5] dead letter list if the attempts
+	// counter reaches ARGV[3] (0 means unlimited) or if ARGV[5] is "1".
+	// The KEYS[4] pending hash entry is then removed, so the feeder can queue
+	// the entry again. Else the entry is pushed back to the tail of its
+	// KEYS[2] queue, named ARGV[2].
+	// When ARGV[7] is "1", the queue has a pending hash, and the requeued
+	// entry is restored in the pending hash with the current enqueue time, as
+	// the job may have dropped it already. If the entry was still pending,
+	// either the job failed before dropping it, or the feeder queued it again
+	// after the drop: it is pushed back only if it is not already in the queue,
+	// so the queue never holds the same entry twice.
+	// ARGV[4] is the error text, ARGV[6] the dead letter list maximum length.
+	// It returns "requeued", "dead_letter", "pending" if the entry is already
+	// queued, or "none" if the entry is not in the processing list anymore.
+	failEntryScript = redis.NewScript(`
+if redis.call('LREM', KEYS[1], 1, ARGV[1]) == 0 then
+	return 'none'
+end
+local field = ARGV[2] .. ' ' .. ARGV[1]
+local n = redis.call('HINCRBY', KEYS[3], field, 1)
+local max = tonumber(ARGV[3])
+if ARGV[5] == '1' or (max > 0 and n >= max) then
+	redis.call('HDEL', KEYS[3], field)
+	redis.call('HDEL', KEYS[4], ARGV[1])
+	local t = redis.call('TIME')
+	redis.call('LPUSH', KEYS[5], cjson.encode({queue=ARGV[2], value=ARGV[1], error=ARGV[4], attempts=n, time=tonumber(t[1])}))
+	redis.call('LTRIM', KEYS[5], 0, tonumber(ARGV[6]) - 1)
+	return 'dead_letter'
+end
+if ARGV[7] == '1' then
+	local t = redis.call('TIME')
+	local enqueuedAt = tostring(t[1] * 1000 + math.floor(t[2] / 1000))
+	if redis.call('HSETNX', KEYS[4], ARGV[1], enqueuedAt) == 0 and redis.call('LPOS', KEYS[2], ARGV[1]) then
+		return 'pending'
+	end
+end
+redis.call('RPUSH', KEYS[2], ARGV[1])
+return 'requeued'
+`)
+)
+
+// newWorkerID returns a worker id unique in the cluster of workers
+func newWorkerID() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.New().String()[:8])
+}
+
+// processingKey returns the key of the worker processing list of queue
+func (w *Worker) processingKey(queue string) string {
+	return cachekeys.FeedProcessingL + w.ID + ":" + queue
+}
+
+// consume moves the queue entries to the worker processing list and runs
+// their jobs with jobCtx, until ctx is done. The queue concurrency slots are
+// acquired before each move, so at most the queue concurrency entries are
+// in the processing list, running or waiting for a runner slot. The jobs are
+// tracked by the jobs wait group.
+func (w *Worker) consume(ctx, jobCtx context.Context, queue string, slots *runSlots, jobs *sync.WaitGroup) {
+	processing := w.processingKey(queue)
+	options := w.queueOptions(queue)
+	queueSlots := make(chan bool, options.Concurrency)
+	for {
+		select {
+		case queueSlots <- true:
+		case <-ctx.Done():
+			return
+		}
+		value, err := w.Redis.BLMove(ctx, queue, processing, "LEFT", "RIGHT", 5*time.Second).Result()
+		switch {
+		case err == nil:
+		case errors.Is(err, redis.Nil):
+			<-queueSlots
+			continue
+		case ctx.Err() != nil:
+			<-queueSlots
+			return
+		default:
+			<-queueSlots
+			slog.Error(fmt.Sprintf("BLMOVE %s %s", queue, processing), logkey.Error, err)
+			time.Sleep(time.Second)
+			continue
+		}
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			defer func() { <-queueSlots }()
+			slots.acquire(queue)
+			defer slots.release()
+			if options.MaxAge > 0 && w.skipStale(jobCtx, queue, value, options.MaxAge) {
+				return
+			}
+			err := w.runJob(jobCtx, []string{queue, value})
+			w.ack(context.WithoutCancel(jobCtx), queue, value, err)
+		}()
+	}
+}
+
+// skipStale acks the entry without running its job if it was queued more
+// than maxAge ago. Its pending hash entry is removed, so the next feeder
+// post queues a fresh entry. The entries of the queues without pending hash
+// are never stale, as their enqueue time is unknown.
+func (w *Worker) skipStale(ctx context.Context, queue, value string, maxAge time.Duration) bool {
+	jt, ok := lookupJobType(queue)
+	if !ok || jt.pendingH() == "" {
+		return false
+	}
+	age, err := skipStaleScript.Run(ctx, w.Redis, []string{jt.pendingH()}, value, maxAge.Milliseconds()).Int64()
+	if err != nil {
+		slog.Warn(fmt.Sprintf("skipStaleScript %s %s", queue, value), logkey.Error, err)
+		return false
+	}
+	if age < 0 {
+		return false
+	}
+	slog.Info(fmt.Sprintf("skip stale %s %s: queued %s ago", queue, value, time.Duration(age)*time.Millisecond))
+	feedStaleEntries.With(prometheus.Labels{"queue": queueName(queue)}).Inc()
+	w.ack(context.WithoutCancel(ctx), queue, value, nil)
+	return true
+}
+
+// ack removes the entry from the worker processing list when the job
+// succeeded. The failed entries are requeued or moved to the dead letter
+// list.
+func (w *Worker) ack(ctx context.Context, queue, value string, jobErr error) {
+	if jobErr != nil {
+		w.failEntry(ctx, w.processingKey(queue), queue, value, failReasonJob, jobErr.Error(), errors.Is(jobErr, errInvalidIndex))
+		return
+	}
+	if _, err := w.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, w.processingKey(queue), 1, value)
+		pipe.HDel(ctx, cachekeys.FeedAttemptsH, queue+" "+value)
+		return nil
+	}); err != nil {
+		slog.Error(fmt.Sprintf("ack %s %s", queue, value), logkey.Error, err)
+	}
+}
+
+// failEntry requeues the failed entry of the processing list, or moves it
+// to the dead letter list when it has reached the maximum attempts or when
+// deadLetter is true.
+func (w *Worker) failEntry(ctx context.Context, processing, queue, value, reason, errText string, deadLetter bool) {
+	// the script needs a key even if the queue has no pending hash
+	pendingH := cachekeys.PendingPrefix + queueName(queue) + cachekeys.PendingSuffix
+	hasPending := "0"
+	if jt, ok := lookupJobType(queue); ok && jt.pendingH() != "" {
+		pendingH = jt.pendingH()
+		hasPending = "1"
+	}
+	keys := []string{processing, queue, cachekeys.FeedAttemptsH, pendingH, cachekeys.FeedDeadLetterL}
+	force := "0"
+	if deadLetter {
+		force = "1"
+	}
+	args := []any{value, queue, w.MaxAttempts, errText, force, deadLetterMaxLength, hasPending}
+	action, err := failEntryScript.Run(ctx, w.Redis, keys, args...).Text()
+	if err != nil {
+		slog.Error(fmt.Sprintf("failEntryScript %s %s", queue, value), logkey.Error, err)
+		return
+	}
+	switch action {
+	case "requeued":
+		slog.Warn(fmt.Sprintf("requeued %s %s: %s", queue, value, errText))
+	case "pending":
+		slog.Warn(fmt.Sprintf("already requeued %s %s: %s", queue, value, errText))
+	case "dead_letter":
+		slog.Error(fmt.Sprintf("moved to %s: %s %s: %s", cachekeys.FeedDeadLetterL, queue, value, errText))
+	default:
+		return
+	}
+	feedFailedEntries.With(prometheus.Labels{"queue": queueName(queue), "reason": reason, "action": action}).Inc()
+}
+
+// heartbeat refreshes the worker heartbeat key until ctx is done. The key
+// expires after w.HeartbeatTimeout, then the worker is considered dead by
+// the other workers reapers.
+func (w *Worker) heartbeat(ctx context.Context) {
+	key := cachekeys.WorkerHeartbeat + w.ID
+	ticker := time.NewTicker(w.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		if err := w.Redis.Set(ctx, key, time.Now().Unix(), w.HeartbeatTimeout).Err(); err != nil && ctx.Err() == nil {
+			slog.Warn("heartbeat", logkey.Error, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reap requeues the entries of the dead workers processing lists every
+// w.HeartbeatInterval, until ctx is done.
+func (w *Worker) reap(ctx context.Context) {
+	ticker := time.NewTicker(w.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := w.reapOnce(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("reap", logkey.Error, err)
+		}
+	}
+}
+
+func (w *Worker) reapOnce(ctx context.Context) error {
+	keys, err := w.scanKeys(ctx, cachekeys.FeedProcessingL+"*")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		// key is oc3:l:feed_processing:<worker id>:<queue key>
+		workerID, queue, ok := strings.Cut(strings.TrimPrefix(key, cachekeys.FeedProcessingL), ":")
+		if !ok || workerID == w.ID {
+			continue
+		}
+		if n, err := w.Redis.Exists(ctx, cachekeys.WorkerHeartbeat+workerID).Result(); err != nil {
+			return fmt.Errorf("EXISTS %s%s: %w", cachekeys.WorkerHeartbeat, workerID, err)
+		} else if n > 0 {
+			continue
+		}
+		values, err := w.Redis.LRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return fmt.Errorf("LRANGE %s: %w", key, err)
+		}
+		if len(values) > 0 {
+			slog.Info(fmt.Sprintf("reap %d entries of dead worker %s queue %s", len(values), workerID, queue))
+		}
+		for _, value := range values {
+			w.failEntry(ctx, key, queue, value, failReasonWorker, "worker "+workerID+" died", false)
+		}
+	}
+	return nil
+}
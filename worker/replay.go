@@ -0,0 +1,214 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/opensvc/oc3/cdb"
+)
+
+type (
+	// Replay runs a job with a captured payload, in a database transaction.
+	//
+	// The payload is stored in the job type data hash of Redis before the
+	// job runs, so use a redis client on a scratch database to not
+	// overwrite the live payloads and caches. The table change events are
+	// recorded instead of published.
+	Replay struct {
+		DB    *sql.DB
+		Redis *redis.Client
+
+		// Type is the job type name, like "daemonStatus", or the queue
+		// name, like "daemon_status".
+		Type string
+
+		// Index is the queue entry of the job, like the node id of the
+		// daemonStatus jobs.
+		Index string
+
+		// Payload is the payload posted to the feeder, as stored in the
+		// job type data hash.
+		Payload []byte
+
+		// DryRun rolls back the transaction and writes the executed SQL
+		// statements and the table changes to Out. The object ids are
+		// created in the rolled back transaction. The Redis database must
+		// be empty, and is flushed after the run, so the job Redis changes
+		// are discarded.
+		DryRun bool
+
+		Out io.Writer
+	}
+
+	// replayDB is the DBOperater of the replayed jobs. It writes the SQL
+	// statements to out in dry run mode, where Commit rolls back.
+	replayDB struct {
+		tx     *sql.Tx
+		out    io.Writer
+		dryRun bool
+	}
+
+	// replayEv records the published events
+	replayEv struct {
+		mu     sync.Mutex
+		events []string
+	}
+)
+
+var (
+	_ cdb.DBOperater = &replayDB{}
+	_ cdb.DBTxer     = &replayDB{}
+)
+
+// ReplayTypes returns the sorted names of the job types that can be
+// replayed.
+func ReplayTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	var l []string
+	for _, jt := range registry {
+		if jt.dataH() != "" {
+			l = append(l, jt.name())
+		}
+	}
+	slices.Sort(l)
+	return l
+}
+
+// Run runs the job with the payload, and removes the payload from the data
+// hash.
+func (r *Replay) Run(ctx context.Context) error {
+	jt, ok := lookupJobTypeByName(r.Type)
+	if !ok {
+		return fmt.Errorf("unknown job type %q, expected one of: %s", r.Type, strings.Join(ReplayTypes(), ", "))
+	}
+	if jt.dataH() == "" {
+		return fmt.Errorf("job type %s can't be replayed: no payload hash", jt.name())
+	}
+	j, err := jt.newJob(r.Index)
+	if err != nil {
+		return err
+	}
+
+	if r.DryRun {
+		// the job caches and pending hashes must not leak to the live
+		// database, nor to the next replays
+		if n, err := r.Redis.DBSize(ctx).Result(); err != nil {
+			return fmt.Errorf("DBSIZE: %w", err)
+		} else if n > 0 {
+			return fmt.Errorf("dry run needs an empty scratch redis database, redis database %d has %d keys", r.Redis.Options().DB, n)
+		}
+		defer func() {
+			_ = r.Redis.FlushDB(context.WithoutCancel(ctx)).Err()
+		}()
+	}
+
+	if err := r.Redis.HSet(ctx, jt.dataH(), r.Index, r.Payload).Err(); err != nil {
+		return fmt.Errorf("HSET %s %s: %w", jt.dataH(), r.Index, err)
+	}
+	defer func() {
+		_ = r.Redis.HDel(context.WithoutCancel(ctx), jt.dataH(), r.Index).Err()
+	}()
+
+	odb := cdb.New(r.DB)
+	odb.ObjectIDsInTx = r.DryRun
+	if err := odb.CreateTx(ctx, nil); err != nil {
+		return fmt.Errorf("create transaction: %w", err)
+	}
+	odb.DB = &replayDB{tx: odb.DB.(*sql.Tx), out: r.Out, dryRun: r.DryRun}
+	ev := &replayEv{}
+	odb.CreateSession(ev)
+
+	if a, ok := j.(RedisSetter); ok {
+		a.SetRedis(r.Redis)
+	}
+	if a, ok := j.(ODBSetter); ok {
+		if err := a.ODBSetter(odb); err != nil {
+			_ = odb.Rollback()
+			return fmt.Errorf("can't setup cdb for %s: %w", j.Name(), err)
+		}
+	} else {
+		_ = odb.Rollback()
+	}
+	if a, ok := j.(EvSetter); ok {
+		a.SetEv(ev)
+	}
+
+	err = RunJob(ctx, j)
+	if r.DryRun {
+		fmt.Fprintf(r.Out, "-- table changes: %s\n", strings.Join(ev.tableChanges(), " "))
+		fmt.Fprintln(r.Out, "-- rolled back")
+	}
+	return err
+}
+
+func (d *replayDB) log(query string, args []any) {
+	if !d.dryRun {
+		return
+	}
+	if len(args) > 0 {
+		fmt.Fprintf(d.out, "%s; -- %v\n", strings.TrimSpace(query), args)
+	} else {
+		fmt.Fprintf(d.out, "%s;\n", strings.TrimSpace(query))
+	}
+}
+
+func (d *replayDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	d.log(query, args)
+	return d.tx.ExecContext(ctx, query, args...)
+}
+
+func (d *replayDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	d.log(query, args)
+	return d.tx.QueryContext(ctx, query, args...)
+}
+
+func (d *replayDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	d.log(query, args)
+	return d.tx.QueryRowContext(ctx, query, args...)
+}
+
+func (d *replayDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	d.log(query, nil)
+	return d.tx.PrepareContext(ctx, query)
+}
+
+func (d *replayDB) Commit() error {
+	if d.dryRun {
+		return d.tx.Rollback()
+	}
+	return d.tx.Commit()
+}
+
+func (d *replayDB) Rollback() error {
+	return d.tx.Rollback()
+}
+
+func (e *replayEv) EventPublish(eventName string, data map[string]any) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, eventName)
+	return nil
+}
+
+// tableChanges returns the sorted names of the tables of the recorded
+// <table>_change events
+func (e *replayEv) tableChanges() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var l []string
+	for _, name := range e.events {
+		if table, ok := strings.CutSuffix(name, "_change"); ok && !slices.Contains(l, table) {
+			l = append(l, table)
+		}
+	}
+	slices.Sort(l)
+	return l
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"sync"
+)
+
+type (
+	// runSlots is the semaphore of the worker runners shared by the queues.
+	// When the runners are busy, the released slots are given to the
+	// waiting queues by smooth weighted round robin, so a queue with weight
+	// 3 gets 3 slots when a queue with weight 1 gets 1.
+	runSlots struct {
+		mu      sync.Mutex
+		free    int
+		weights map[string]int
+		current map[string]int
+		waiters map[string][]chan struct{}
+	}
+)
+
+func newRunSlots(n int, weights map[string]int) *runSlots {
+	return &runSlots{
+		free:    n,
+		weights: weights,
+		current: make(map[string]int),
+		waiters: make(map[string][]chan struct{}),
+	}
+}
+
+// acquire blocks until a runner slot is given to queue
+func (r *runSlots) acquire(queue string) {
+	r.mu.Lock()
+	if r.free > 0 && r.waiting() == 0 {
+		r.free--
+		r.mu.Unlock()
+		return
+	}
+	c := make(chan struct{})
+	r.waiters[queue] = append(r.waiters[queue], c)
+	r.mu.Unlock()
+	<-c
+}
+
+// release gives the runner slot to the next waiting queue, or frees it
+func (r *runSlots) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var (
+		next  string
+		total int
+	)
+	for queue, l := range r.waiters {
+		if len(l) == 0 {
+			continue
+		}
+		weight := r.weight(queue)
+		total += weight
+		r.current[queue] += weight
+		if next == "" || r.current[queue] > r.current[next] {
+			next = queue
+		}
+	}
+	if next == "" {
+		r.free++
+		return
+	}
+	r.current[next] -= total
+	c := r.waiters[next][0]
+	r.waiters[next] = r.waiters[next][1:]
+	close(c)
+}
+
+func (r *runSlots) waiting() (n int) {
+	for _, l := range r.waiters {
+		n += len(l)
+	}
+	return
+}
+
+func (r *runSlots) weight(queue string) int {
+	if weight, ok := r.weights[queue]; ok && weight > 0 {
+		return weight
+	}
+	return 1
+}
@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/opensvc/oc3/cachekeys"
 	"github.com/opensvc/oc3/cdb"
 	"github.com/opensvc/oc3/util/logkey"
 )
@@ -29,9 +29,72 @@ type (
 		// Runners is the maximum number of jobs to run in parallel.
 		Runners int
 
+		// QueueOptions are the per queue options, indexed by queue key.
+		QueueOptions map[string]QueueOptions
+
+		// ID is the worker id, unique in the cluster of workers. It names the
+		// worker processing lists and heartbeat key. A random id is used
+		// when empty.
+		ID string
+
+		// MaxAttempts is the number of failed runs after which a queue
+		// entry is moved to the dead letter list. Zero means unlimited.
+		MaxAttempts int
+
+		// HeartbeatInterval is the worker heartbeat refresh interval, and
+		// the dead worker reaper interval.
+		HeartbeatInterval time.Duration
+
+		// HeartbeatTimeout is the delay after which a worker not refreshing
+		// its heartbeat is considered dead, and its in-flight queue entries
+		// are requeued.
+		HeartbeatTimeout time.Duration
+
+		// CaptureDir is the directory where the job payloads of the
+		// CaptureNodeIDs nodes are archived. No payload is archived when
+		// empty.
+		CaptureDir     string
+		CaptureNodeIDs []string
+
+		// DrainTimeout is the maximum delay to wait for the running jobs to
+		// finish on shutdown, before canceling them.
+		DrainTimeout time.Duration
+
+		// StatusMergeRules override the default merge rules of the
+		// hypervisor and encap instance statuses, by status kind.
+		StatusMergeRules map[string][]StatusMergeRule
+
+		// SeverityRules are the configured dashboard severity rules, applied
+		// with the rules of the dashboard_severity_rules table.
+		SeverityRules []cdb.DashboardSeverityRule
+
+		// SeverityRulesInterval is the dashboard severity rules reload
+		// interval. The rules are also reloaded when the server changes
+		// them. The rules are not loaded when zero.
+		SeverityRulesInterval time.Duration
+
+		// QueueMetricsInterval is the feed queues metrics update interval.
+		// The queues metrics are not updated when zero.
+		QueueMetricsInterval time.Duration
+
 		SubSystem string
 	}
 
+	// QueueOptions are the options of a worker queue.
+	QueueOptions struct {
+		// Concurrency is the maximum number of jobs of the queue running or
+		// waiting for a runner. Zero means Worker.Runners.
+		Concurrency int
+
+		// Weight is the share of the runners given to the queue when the
+		// runners are busy, relative to the other queues weight. Zero means 1.
+		Weight int
+
+		// MaxAge is the maximum delay between the enqueue of an entry and
+		// its job start. The older entries are skipped. Zero means no limit.
+		MaxAge time.Duration
+	}
+
 	EventPublisher interface {
 		EventPublish(eventName string, data map[string]any) error
 	}
@@ -48,6 +111,10 @@ type (
 		SetEv(ev EventPublisher)
 	}
 
+	ResultPublisher interface {
+		publishResult(ctx context.Context, err error) error
+	}
+
 	JobRunner interface {
 		Operationer
 
@@ -61,6 +128,8 @@ type (
 
 const (
 	// job types
+	jtChecks               = "checks"
+	jtDaemonEvents         = "daemonEvents"
 	jtDaemonPing           = "daemonPing"
 	jtDaemonStatus         = "daemonStatus"
 	jtInstanceAction       = "instanceAction"
@@ -69,109 +138,115 @@ const (
 	jtNodeDisk             = "nodeDisk"
 	jtNodeSystem           = "nodeSystem"
 	jtObjectConfig         = "objectConfig"
+	jtSysreport            = "sysreport"
 
 	// job or job step statuses
 	jobStatusOk     = "ok"
 	jobStatusFailed = "failed"
 )
 
-func (w *Worker) Run() error {
-	slog.Info(fmt.Sprintf("starting %d runners for queues: %s", w.Runners, strings.Join(w.Queues, ", ")))
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	jobC := make(chan []string, w.Runners)
-	runSlots := make(chan bool, w.Runners)
-	go func(c <-chan []string) {
-		for {
-			select {
-			case j := <-c:
-				runSlots <- true
-				go func(j []string) {
-					defer func() { <-runSlots }()
-					if err := w.runJob(j); err != nil {
-						slog.Error("job failed", logkey.Error, err)
-					}
-				}(j)
-			case <-ctx.Done():
-				return
-			}
-		}
-	}(jobC)
-
-	for {
-		unqueuedCmd := w.Redis.BLPop(context.Background(), 5*time.Second, w.Queues...)
-		unqueuedResult, err := unqueuedCmd.Result()
-		switch err {
-		case nil:
-		case redis.Nil:
-			continue
-		default:
-			slog.Error(err.Error())
-			time.Sleep(time.Second)
-			continue
-		}
-		jobC <- unqueuedResult
+// Run consumes the worker queues until ctx is done.
+//
+// The queue entries are moved to a worker processing list while their job
+// runs, and removed from this list when the job succeeds. The failed
+// entries are pushed back to their queue, until they reach w.MaxAttempts
+// and are moved to the dead letter list. The entries of the processing lists
+// of the workers that stopped refreshing their heartbeat are requeued by the
+// alive workers.
+//
+// When ctx is done, Run stops dequeuing and waits for the running jobs to
+// finish. The jobs still running after w.DrainTimeout are canceled.
+func (w *Worker) Run(ctx context.Context) error {
+	if w.ID == "" {
+		w.ID = newWorkerID()
+	}
+	if w.HeartbeatInterval <= 0 {
+		w.HeartbeatInterval = 5 * time.Second
+	}
+	if w.HeartbeatTimeout <= w.HeartbeatInterval {
+		w.HeartbeatTimeout = 6 * w.HeartbeatInterval
+	}
+	if err := setStatusMergeRules(w.StatusMergeRules); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("starting worker %s with %d runners for queues: %s", w.ID, w.Runners, strings.Join(w.Queues, ", ")))
+
+	// jobCtx outlives ctx during the drain, so the running jobs can
+	// finish and the heartbeat still protects their processing list entries.
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+	if w.QueueMetricsInterval > 0 {
+		go w.watchQueues(ctx, w.QueueMetricsInterval)
+	}
+	if w.SeverityRulesInterval > 0 {
+		go w.watchSeverityRules(ctx, w.SeverityRulesInterval)
+	}
+	go w.heartbeat(jobCtx)
+	go w.reap(ctx)
+
+	var (
+		consumers sync.WaitGroup
+		jobs      sync.WaitGroup
+	)
+	weights := make(map[string]int)
+	for _, queue := range w.Queues {
+		weights[queue] = w.queueOptions(queue).Weight
 	}
+	slots := newRunSlots(w.Runners, weights)
+	for _, queue := range w.Queues {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			w.consume(ctx, jobCtx, queue, slots, &jobs)
+		}()
+	}
+	consumers.Wait()
+
+	slog.Info(fmt.Sprintf("stopped dequeuing, drain the running jobs with timeout %s", w.DrainTimeout))
+	drained := make(chan struct{})
+	go func() {
+		jobs.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		slog.Info("drained")
+	case <-time.After(w.DrainTimeout):
+		slog.Warn("drain timeout, cancel the running jobs")
+		cancelJobs()
+		<-drained
+	}
+	return nil
 }
 
-func (w *Worker) runJob(unqueuedJob []string) error {
-	begin := time.Now()
-	var j JobRunner
-	slog.Debug(fmt.Sprintf("BLPOP %s -> %s", unqueuedJob[0], unqueuedJob[1]))
-	ctx := context.Background()
-	switch unqueuedJob[0] {
-	case cachekeys.FeedDaemonPingQ:
-		j = newDaemonPing(unqueuedJob[1])
-	case cachekeys.FeedDaemonStatusQ:
-		j = newDaemonStatus(unqueuedJob[1])
-	case cachekeys.FeedInstanceResourceInfoQ:
-		objectName, nodeID, ClusterID, err := w.jobToInstanceAndClusterID(unqueuedJob[1])
-		if err != nil {
-			err := fmt.Errorf("invalid feed instance resource info index: %w", err)
-			slog.Warn(err.Error())
-			return err
-		}
-		j = newjobFeedInstanceResourceInfo(objectName, nodeID, ClusterID)
-	case cachekeys.FeedNodeDiskQ:
-		// expected unqueuedJob[1]: <nodename>@<nodeID>@<clusterID>
-		l := strings.Split(unqueuedJob[1], "@")
-		if len(l) != 3 || l[0] == "" || l[1] == "" || l[2] == "" {
-			slog.Warn(fmt.Sprintf("invalid feed node disk index expected `nodename`@`nodeID`@`clusterID` found: %s", unqueuedJob[1]))
-			return fmt.Errorf("invalid feed node disk index expected `nodename`@`nodeID`@`clusterID` found: %s", unqueuedJob[1])
-		}
-		j = newNodeDisk(l[0], l[1], l[2])
-	case cachekeys.FeedObjectConfigQ:
-		objectName, nodeID, ClusterID, err := w.jobToInstanceAndClusterID(unqueuedJob[1])
-		if err != nil {
-			err := fmt.Errorf("invalid feed instance config index: %s", unqueuedJob[1])
-			slog.Warn(err.Error())
-			return err
-		}
-		j = newFeedObjectConfig(objectName, nodeID, ClusterID)
-	case cachekeys.FeedSystemQ:
-		j = newDaemonSystem(unqueuedJob[1])
-	case cachekeys.FeedInstanceStatusQ:
-		objectName, nodeID, clusterID, err := w.jobToInstanceAndClusterID(unqueuedJob[1])
-		if err != nil {
-			err := fmt.Errorf("invalid feed instance status index: %s", unqueuedJob[1])
-			slog.Warn(err.Error())
-			return err
-		}
-		j = newInstanceStatus(objectName, nodeID, clusterID)
-
-	case cachekeys.FeedInstanceActionQ:
-		objectName, nodeID, ClusterID, uuid, err := w.jobToInstanceClusterIdAndUuid(unqueuedJob[1])
-		if err != nil {
-			err := fmt.Errorf("invalid feed begin action index: %s", unqueuedJob[1])
-			slog.Warn(err.Error())
-			return err
-		}
-		j = newAction(objectName, nodeID, ClusterID, uuid)
+// queueOptions returns the queue options, with the defaults applied
+func (w *Worker) queueOptions(queue string) QueueOptions {
+	options := w.QueueOptions[queue]
+	if options.Concurrency <= 0 || options.Concurrency > w.Runners {
+		options.Concurrency = w.Runners
+	}
+	if options.Weight <= 0 {
+		options.Weight = 1
+	}
+	return options
+}
 
-	default:
+func (w *Worker) runJob(ctx context.Context, unqueuedJob []string) error {
+	begin := time.Now()
+	slog.Debug(fmt.Sprintf("BLMOVE %s -> %s", unqueuedJob[0], unqueuedJob[1]))
+	jt, ok := lookupJobType(unqueuedJob[0])
+	if !ok {
 		slog.Debug(fmt.Sprintf("ignore queue '%s'", unqueuedJob[0]))
 		return nil
 	}
+	j, err := jt.newJob(unqueuedJob[1])
+	if err != nil {
+		slog.Warn(err.Error())
+		return err
+	}
+	if err := w.capture(ctx, jt, unqueuedJob[1]); err != nil {
+		slog.Warn(fmt.Sprintf("capture %s %s", unqueuedJob[0], unqueuedJob[1]), logkey.Error, err)
+	}
 	jName := j.Name()
 	jlog := j.Logger()
 
@@ -194,43 +269,19 @@ func (w *Worker) runJob(unqueuedJob []string) error {
 		a.SetEv(w.Ev)
 	}
 	status := jobStatusOk
-	err := RunJob(ctx, j)
+	err = RunJob(ctx, j)
 	duration := time.Since(begin)
 	if err != nil {
 		status = jobStatusFailed
 		jlog.Error("🔴job failure", logkey.Error, err, logkey.JobDetail, j.Detail())
 	}
+	if a, ok := j.(ResultPublisher); ok {
+		if err := a.publishResult(ctx, err); err != nil {
+			jlog.Warn("publish job result", logkey.Error, err)
+		}
+	}
 	feedJobCounter.With(prometheus.Labels{"job_type": jName, "status": status}).Inc()
 	feedJobDuration.With(prometheus.Labels{"job_type": jName, "status": status}).Observe(duration.Seconds())
-	jlog.Debug(fmt.Sprintf("BLPOP %s <- %s: %s", unqueuedJob[0], unqueuedJob[1], duration))
-	return nil
-}
-
-// jobToInstanceAndClusterID splits a jobName string into path, nodeID, and clusterID based on "@" delimiter.
-// Returns an error if the format is invalid or elements are empty.
-// Expected jobName: foo@<nodeID>@<clusterID>
-func (w *Worker) jobToInstanceAndClusterID(jobName string) (path, nodeID, clusterID string, err error) {
-	l := strings.Split(jobName, "@")
-	if len(l) != 3 || l[0] == "" || l[1] == "" || l[2] == "" {
-		err = fmt.Errorf("unexpected job name: %s", jobName)
-	} else {
-		path = l[0]
-		nodeID = l[1]
-		clusterID = l[2]
-	}
-	return
-}
-
-func (w *Worker) jobToInstanceClusterIdAndUuid(jobName string) (path, nodeID, clusterID, uuid string, err error) {
-	l := strings.Split(jobName, ":")
-	if len(l) != 2 {
-		err = fmt.Errorf("unexpected job name: %s", jobName)
-		return
-	}
-	if path, nodeID, clusterID, err = w.jobToInstanceAndClusterID(l[0]); err != nil {
-		err = fmt.Errorf("unexpected job name: %s", jobName)
-		return
-	}
-	uuid = l[1]
-	return
+	jlog.Debug(fmt.Sprintf("BLMOVE %s <- %s: %s", unqueuedJob[0], unqueuedJob[1], duration))
+	return err
 }